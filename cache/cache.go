@@ -0,0 +1,131 @@
+// Package cache keeps the state of guilds, channels, roles, members and
+// more, kept up to date by gateway events. Cache keeps it in memory,
+// RedisCache shares it between services.
+//
+// Values returned by a cache must not be modified. The memory cache shares
+// them with every caller and never modifies a value it returned either,
+// updates replace it.
+package cache
+
+import (
+	"time"
+
+	"github.com/rs/zerolog"
+	"wumpgo.dev/wumpgo/objects"
+)
+
+// Entity selects the kinds of state that are cached.
+type Entity uint
+
+const (
+	EntityGuilds Entity = 1 << iota
+	EntityChannels
+	EntityRoles
+	EntityMembers
+	EntityUsers
+	EntityPresences
+	EntityVoiceStates
+
+	EntityNone Entity = 0
+	EntityAll         = EntityGuilds | EntityChannels | EntityRoles | EntityMembers |
+		EntityUsers | EntityPresences | EntityVoiceStates
+)
+
+// State is the read API shared by every cache.
+type State interface {
+	// Me returns the current user, as received in READY.
+	Me() (*objects.User, bool)
+	// Guild returns a cached guild. Its roles, channels, members, presences
+	// and voice states are cached separately and not set on the guild.
+	Guild(id objects.Snowflake) (*objects.Guild, bool)
+	// Guilds returns every cached guild.
+	Guilds() []*objects.Guild
+	// Channel returns a cached channel or thread.
+	Channel(id objects.Snowflake) (*objects.Channel, bool)
+	// Channels returns the cached channels and threads of a guild.
+	Channels(guild objects.Snowflake) []*objects.Channel
+	// Role returns a cached role.
+	Role(id objects.Snowflake) (*objects.Role, bool)
+	// Roles returns the cached roles of a guild.
+	Roles(guild objects.Snowflake) []*objects.Role
+	// Member returns a cached guild member.
+	Member(guild, user objects.Snowflake) (*objects.GuildMember, bool)
+	// Members returns the cached members of a guild. Without the guild
+	// members intent only a few members, such as those in voice channels,
+	// are cached.
+	Members(guild objects.Snowflake) []*objects.GuildMember
+	// User returns a cached user.
+	User(id objects.Snowflake) (*objects.User, bool)
+	// Presence returns the cached presence of a guild member.
+	Presence(guild, user objects.Snowflake) (*objects.PresenceUpdate, bool)
+	// VoiceState returns the cached voice state of a guild member.
+	VoiceState(guild, user objects.Snowflake) (*objects.VoiceState, bool)
+	// VoiceStates returns the voice states of every guild member that is
+	// connected to a voice channel.
+	VoiceStates(guild objects.Snowflake) []*objects.VoiceState
+}
+
+// Updater is implemented by caches that can be fed gateway events.
+type Updater interface {
+	// Update applies a gateway event to the cache. payload is a decoded
+	// event, e.g. *objects.GuildCreate. Events that don't affect the cache
+	// are ignored.
+	Update(payload interface{}) error
+}
+
+type config struct {
+	entities Entity
+	limits   map[Entity]int
+	timeout  time.Duration
+	log      zerolog.Logger
+}
+
+func newConfig(opts []Option) *config {
+	conf := &config{
+		entities: EntityAll,
+		limits:   make(map[Entity]int),
+		timeout:  5 * time.Second,
+		log:      zerolog.Nop(),
+	}
+
+	for _, o := range opts {
+		o(conf)
+	}
+
+	return conf
+}
+
+type Option func(*config)
+
+// WithEntities sets the kinds of state that are cached. Defaults to
+// EntityAll.
+func WithEntities(e Entity) Option {
+	return func(c *config) {
+		c.entities = e
+	}
+}
+
+// WithLimit caps the number of cached entities of a kind in a memory cache.
+// The least recently used entities are evicted first. Limits are best used
+// for members, users and presences, lookups that return every entity of a
+// guild, such as Roles, only return what is left in the cache.
+func WithLimit(e Entity, limit int) Option {
+	return func(c *config) {
+		c.limits[e] = limit
+	}
+}
+
+// WithTimeout bounds every Redis operation of a RedisCache. Defaults to 5
+// seconds.
+func WithTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.timeout = d
+	}
+}
+
+// WithLogger sets the logger a RedisCache reports failed lookups to.
+func WithLogger(l zerolog.Logger) Option {
+	return func(c *config) {
+		c.log = l
+	}
+}
@@ -0,0 +1,310 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wumpgo.dev/wumpgo/gateway/dispatcher"
+	"wumpgo.dev/wumpgo/gateway/receiver"
+	"wumpgo.dev/wumpgo/objects"
+	"wumpgo.dev/wumpgo/rest"
+)
+
+const guildCreatePayload = `{
+	"id": "1",
+	"name": "guild",
+	"roles": [{"id": "1", "name": "@everyone"}, {"id": "2", "name": "mod"}],
+	"channels": [{"id": "10", "name": "general"}],
+	"threads": [{"id": "11", "name": "thread", "parent_id": "10"}],
+	"members": [{"user": {"id": "100", "username": "wumpus"}, "roles": ["2"]}],
+	"voice_states": [{"channel_id": "10", "user_id": "100"}]
+}`
+
+type testCache interface {
+	State
+	Updater
+}
+
+func newRedisTestCache(t *testing.T, opts ...Option) *RedisCache {
+	srv := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	return NewRedisCache(rdb, "test:", opts...)
+}
+
+func TestCache_GuildLifecycle(t *testing.T) {
+	caches := map[string]testCache{
+		"memory": New(),
+		"redis":  newRedisTestCache(t),
+	}
+
+	for name, c := range caches {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, Handle(c, "GUILD_CREATE", []byte(guildCreatePayload)))
+
+			g, ok := c.Guild(1)
+			require.True(t, ok)
+			assert.Equal(t, "guild", g.Name)
+			assert.Nil(t, g.Roles)
+			assert.Nil(t, g.Channels)
+
+			assert.Len(t, c.Roles(1), 2)
+			assert.Len(t, c.Channels(1), 2)
+
+			ch, ok := c.Channel(10)
+			require.True(t, ok)
+			assert.Equal(t, objects.Snowflake(1), ch.GuildID)
+
+			m, ok := c.Member(1, 100)
+			require.True(t, ok)
+			assert.Equal(t, []objects.Snowflake{2}, m.Roles)
+
+			u, ok := c.User(100)
+			require.True(t, ok)
+			assert.Equal(t, "wumpus", u.Username)
+
+			vs, ok := c.VoiceState(1, 100)
+			require.True(t, ok)
+			assert.Equal(t, objects.Snowflake(1), vs.GuildID)
+
+			require.NoError(t, Handle(c, "GUILD_ROLE_DELETE", []byte(`{"guild_id": "1", "role_id": "2"}`)))
+			assert.Len(t, c.Roles(1), 1)
+			_, ok = c.Role(2)
+			assert.False(t, ok)
+
+			require.NoError(t, Handle(c, "VOICE_STATE_UPDATE", []byte(`{"guild_id": "1", "user_id": "100"}`)))
+			_, ok = c.VoiceState(1, 100)
+			assert.False(t, ok)
+
+			// An outage keeps the guild's state around.
+			require.NoError(t, Handle(c, "GUILD_DELETE", []byte(`{"id": "1", "unavailable": true}`)))
+			g, ok = c.Guild(1)
+			require.True(t, ok)
+			assert.True(t, g.Unavailable)
+			assert.Len(t, c.Channels(1), 2)
+
+			require.NoError(t, Handle(c, "GUILD_DELETE", []byte(`{"id": "1"}`)))
+			_, ok = c.Guild(1)
+			assert.False(t, ok)
+			_, ok = c.Channel(10)
+			assert.False(t, ok)
+			assert.Empty(t, c.Channels(1))
+			assert.Empty(t, c.Roles(1))
+			assert.Empty(t, c.Members(1))
+		})
+	}
+}
+
+func TestCache_ConcurrentGuildUpdates(t *testing.T) {
+	caches := map[string]testCache{
+		"memory": New(),
+		"redis":  newRedisTestCache(t),
+	}
+
+	for name, c := range caches {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, Handle(c, "GUILD_CREATE", []byte(guildCreatePayload)))
+
+			// Both events rewrite the guild from what they read, an update
+			// that isn't serialized loses the other one's field.
+			for i := 0; i < 20; i++ {
+				var wg sync.WaitGroup
+				wg.Add(2)
+				go func() {
+					defer wg.Done()
+					assert.NoError(t, Handle(c, "GUILD_EMOJIS_UPDATE", []byte(`{"guild_id": "1", "emojis": [{"id": "20"}]}`)))
+				}()
+				go func() {
+					defer wg.Done()
+					assert.NoError(t, Handle(c, "GUILD_STICKERS_UPDATE", []byte(`{"guild_id": "1", "stickers": [{"id": "30"}]}`)))
+				}()
+				wg.Wait()
+
+				g, ok := c.Guild(1)
+				require.True(t, ok)
+				require.Len(t, g.Emojis, 1)
+				require.Len(t, g.Stickers, 1)
+
+				require.NoError(t, Handle(c, "GUILD_EMOJIS_UPDATE", []byte(`{"guild_id": "1", "emojis": []}`)))
+				require.NoError(t, Handle(c, "GUILD_STICKERS_UPDATE", []byte(`{"guild_id": "1", "stickers": []}`)))
+			}
+		})
+	}
+}
+
+func TestRedisCache_IgnoredEvent(t *testing.T) {
+	srv := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	c := NewRedisCache(rdb, "test:")
+
+	require.NoError(t, Handle(c, "TYPING_START", []byte(`{"channel_id": "10", "user_id": "100"}`)))
+	assert.Empty(t, srv.Keys())
+
+	// A guild recreated with fewer roles drops the stale index entries.
+	require.NoError(t, Handle(c, "GUILD_CREATE", []byte(guildCreatePayload)))
+	require.NoError(t, Handle(c, "GUILD_CREATE", []byte(`{"id": "1", "name": "guild", "roles": [{"id": "1"}]}`)))
+	_, ok := c.Role(2)
+	assert.False(t, ok)
+	_, ok = c.Role(1)
+	assert.True(t, ok)
+}
+
+func TestCache_Limits(t *testing.T) {
+	c := New(WithEntities(EntityGuilds|EntityUsers), WithLimit(EntityUsers, 2))
+
+	for _, id := range []string{"1", "2", "3"} {
+		require.NoError(t, Handle(c, "GUILD_MEMBER_ADD", []byte(`{"guild_id": "1", "user": {"id": "`+id+`"}}`)))
+	}
+
+	_, ok := c.User(1)
+	assert.False(t, ok, "least recently used user should be evicted")
+	_, ok = c.User(3)
+	assert.True(t, ok)
+
+	_, ok = c.Member(1, 3)
+	assert.False(t, ok, "members are not cached")
+}
+
+func TestCache_Register(t *testing.T) {
+	c := New()
+	r := receiver.NewLocalReceiver()
+	require.NoError(t, Register(c, r))
+
+	var name string
+	r.OnChannelUpdate(func(_ context.Context, _ rest.RESTClient, e *objects.ChannelUpdate) {
+		ch, _ := c.Channel(e.ID)
+		name = ch.Name
+	})
+
+	d := NewDispatcher(c, dispatcher.NewLocalDispatcher(receiver.NewLocalReceiver()))
+	require.NoError(t, d.Dispatch("CHANNEL_CREATE", []byte(`{"id": "5", "name": "old"}`)))
+	_, ok := c.Channel(5)
+	assert.True(t, ok)
+
+	require.NoError(t, r.Route("CHANNEL_UPDATE", []byte(`{"id": "5", "name": "new"}`)))
+	assert.Equal(t, "new", name)
+}
+
+func TestMessageCache(t *testing.T) {
+	m := NewMessageCache(MessageCacheConfig{MaxPerChannel: 2})
+
+	var events []string
+	r := receiver.NewLocalReceiver(receiver.WithMiddleware(func(next receiver.NextFunc) receiver.NextFunc {
+		return func(ctx context.Context, event string, payload interface{}) error {
+			events = append(events, event)
+			return next(ctx, event, payload)
+		}
+	}))
+	require.NoError(t, m.Register(r))
+
+	var update *MessageUpdate
+	_, err := r.On(func(_ context.Context, _ rest.RESTClient, e *MessageUpdate) { update = e })
+	require.NoError(t, err)
+	var bulk *MessageDeleteBulk
+	_, err = r.On(func(_ context.Context, _ rest.RESTClient, e *MessageDeleteBulk) { bulk = e })
+	require.NoError(t, err)
+
+	for _, id := range []string{"1", "2", "3"} {
+		require.NoError(t, r.Route("MESSAGE_CREATE", []byte(`{"id": "`+id+`", "channel_id": "10", "author": {"id": "5"}, "content": "v1"}`)))
+	}
+
+	_, ok := m.Message(10, 1)
+	assert.False(t, ok, "least recently used message should be evicted")
+
+	require.NoError(t, r.Route("MESSAGE_UPDATE", []byte(`{"id": "2", "channel_id": "10", "author": {"id": "5"}, "content": "v2"}`)))
+	require.NotNil(t, update)
+	require.NotNil(t, update.Before)
+	assert.Equal(t, "v1", update.Before.Content)
+	assert.Equal(t, "v2", update.Content)
+	assert.Contains(t, events, "cached_message_update", "cached events go through the middleware")
+
+	msg, ok := m.Message(10, 2)
+	require.True(t, ok)
+	assert.Equal(t, "v2", msg.Content)
+
+	require.NoError(t, r.Route("MESSAGE_DELETE_BULK", []byte(`{"ids": ["1", "2", "3"], "channel_id": "10"}`)))
+	require.NotNil(t, bulk)
+	assert.Len(t, bulk.Messages, 2)
+	assert.Empty(t, m.Messages(10))
+}
+
+func TestMessageCache_WaitFor(t *testing.T) {
+	m := NewMessageCache(MessageCacheConfig{})
+	r := receiver.NewLocalReceiver(receiver.WithWorkerPool(receiver.WorkerPoolConfig{Workers: 1}))
+	require.NoError(t, m.Register(r))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() { errs <- r.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		<-errs
+	})
+
+	deleted := make(chan *MessageDelete, 1)
+	go func() {
+		e, err := receiver.WaitFor(ctx, r, func(e *MessageDelete) bool { return e.ID == 1 })
+		assert.NoError(t, err)
+		deleted <- e
+	}()
+
+	require.NoError(t, r.Route("MESSAGE_CREATE", []byte(`{"id": "1", "channel_id": "10", "author": {"id": "5"}, "content": "hi"}`)))
+	assert.Eventually(t, func() bool {
+		_, ok := m.Message(10, 1)
+		return ok
+	}, time.Second, 5*time.Millisecond)
+	require.NoError(t, r.Route("MESSAGE_DELETE", []byte(`{"id": "1", "channel_id": "10"}`)))
+
+	select {
+	case e := <-deleted:
+		require.NotNil(t, e)
+		require.NotNil(t, e.Message)
+		assert.Equal(t, "hi", e.Message.Content)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+}
+
+func TestMessageCache_PartialUpdate(t *testing.T) {
+	m := NewMessageCache(MessageCacheConfig{})
+	r := receiver.NewLocalReceiver()
+	require.NoError(t, m.Register(r))
+
+	require.NoError(t, r.Route("MESSAGE_CREATE", []byte(`{"id": "1", "channel_id": "10", "author": {"id": "5"}, "content": "hi", "embeds": [{"title": "a"}], "pinned": true}`)))
+	require.NoError(t, r.Route("MESSAGE_UPDATE", []byte(`{"id": "1", "channel_id": "10", "embeds": [], "flags": 4}`)))
+
+	msg, ok := m.Message(10, 1)
+	require.True(t, ok)
+	assert.Equal(t, "hi", msg.Content)
+	assert.Equal(t, objects.Snowflake(5), msg.Author.ID)
+	assert.True(t, msg.Pinned)
+	assert.Empty(t, msg.Embeds)
+	assert.Equal(t, objects.MessageFlag(4), msg.Flags)
+}
+
+func TestMessageCache_MaxAge(t *testing.T) {
+	m := NewMessageCache(MessageCacheConfig{MaxAge: 50 * time.Millisecond})
+	r := receiver.NewLocalReceiver()
+	require.NoError(t, m.Register(r))
+
+	require.NoError(t, r.Route("MESSAGE_CREATE", []byte(`{"id": "1", "channel_id": "10", "author": {"id": "5"}}`)))
+	_, ok := m.Message(10, 1)
+	require.True(t, ok)
+
+	time.Sleep(60 * time.Millisecond)
+	_, ok = m.Message(10, 1)
+	assert.False(t, ok)
+
+	// Expired messages are evicted once the next message comes in.
+	require.NoError(t, r.Route("MESSAGE_CREATE", []byte(`{"id": "2", "channel_id": "11", "author": {"id": "5"}}`)))
+	_, ok = m.channels.get(10)
+	assert.False(t, ok)
+	assert.Equal(t, 1, m.channels.len())
+}
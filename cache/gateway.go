@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"wumpgo.dev/wumpgo/gateway/dispatcher"
+	"wumpgo.dev/wumpgo/gateway/receiver"
+	"wumpgo.dev/wumpgo/objects"
+	"wumpgo.dev/wumpgo/rest"
+)
+
+// events are the gateway events that update the cache.
+var events = map[string]struct{}{
+	receiver.EventReady:               {},
+	receiver.EventUserUpdate:          {},
+	receiver.EventGuildCreate:         {},
+	receiver.EventGuildUpdate:         {},
+	receiver.EventGuildDelete:         {},
+	receiver.EventGuildEmojisUpdate:   {},
+	receiver.EventGuildStickersUpdate: {},
+	receiver.EventChannelCreate:       {},
+	receiver.EventChannelUpdate:       {},
+	receiver.EventChannelDelete:       {},
+	receiver.EventChannelPinsUpdate:   {},
+	receiver.EventThreadCreate:        {},
+	receiver.EventThreadUpdate:        {},
+	receiver.EventThreadDelete:        {},
+	receiver.EventThreadListSync:      {},
+	receiver.EventGuildRoleCreate:     {},
+	receiver.EventGuildRoleUpdate:     {},
+	receiver.EventGuildRoleDelete:     {},
+	receiver.EventGuildMemberAdd:      {},
+	receiver.EventGuildMemberUpdate:   {},
+	receiver.EventGuildMemberRemove:   {},
+	receiver.EventGuildMembersChunk:   {},
+	receiver.EventPresenceUpdate:      {},
+	receiver.EventVoiceStateUpdate:    {},
+}
+
+// Register keeps a cache up to date with the events routed by r. It should
+// be called before any other handler is registered, so that handlers see the
+// cache with their event already applied. The cache shares the decoded
+// events with the receiver's other handlers.
+func Register(c Updater, r receiver.Receiver) error {
+	handlers := []receiver.HandlerFunc{
+		func(_ context.Context, _ rest.RESTClient, e *objects.Ready) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.UserUpdate) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.GuildCreate) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.GuildUpdate) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.GuildDelete) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.GuildEmojisUpdate) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.GuildStickersUpdate) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.ChannelCreate) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.ChannelUpdate) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.ChannelDelete) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.ChannelPinsUpdate) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.ThreadCreate) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.ThreadUpdate) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.ThreadDelete) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.ThreadListSync) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.GuildRoleCreate) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.GuildRoleUpdate) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.GuildRoleDelete) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.GuildMemberAdd) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.GuildMemberUpdate) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.GuildMemberRemove) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.GuildMembersChunk) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.PresenceUpdate) error { return update(c, e) },
+		func(_ context.Context, _ rest.RESTClient, e *objects.VoiceStateUpdate) error { return update(c, e) },
+	}
+
+	for _, h := range handlers {
+		if _, err := r.On(h); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Handle decodes a raw gateway event and applies it to a cache.
+func Handle(c Updater, event string, data json.RawMessage) error {
+	event = strings.ToUpper(event)
+	if _, ok := events[event]; !ok {
+		return nil
+	}
+
+	payload, ok := receiver.NewPayload(event)
+	if !ok {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, payload); err != nil {
+		return err
+	}
+
+	return c.Update(payload)
+}
+
+// update applies an event routed by a receiver.
+func update(c Updater, payload interface{}) error {
+	if err := c.Update(payload); err != nil {
+		return fmt.Errorf("failed to update cache: %w", err)
+	}
+	return nil
+}
+
+var _ dispatcher.MetadataDispatcher = (*Dispatcher)(nil)
+
+// Dispatcher updates a cache with every event before passing it on, e.g. to
+// a dispatcher.LocalDispatcher, or to NATS with a RedisCache that workers
+// read from.
+type Dispatcher struct {
+	cache  Updater
+	next   dispatcher.Dispatcher
+	logger *zerolog.Logger
+}
+
+// NewDispatcher returns a dispatcher that updates c before dispatching
+// events to next.
+func NewDispatcher(c Updater, next dispatcher.Dispatcher) *Dispatcher {
+	logger := zerolog.Nop()
+	return &Dispatcher{cache: c, next: next, logger: &logger}
+}
+
+func (d *Dispatcher) Dispatch(event string, data json.RawMessage) error {
+	return d.DispatchWithMetadata(receiver.UnknownMetadata(), event, data)
+}
+
+func (d *Dispatcher) DispatchWithMetadata(meta dispatcher.Metadata, event string, data json.RawMessage) error {
+	if err := Handle(d.cache, event, data); err != nil {
+		d.logger.Warn().Err(err).Str("event", event).Msg("failed to update cache")
+	}
+	return dispatcher.DispatchWithMetadata(d.next, meta, event, data)
+}
+
+func (d *Dispatcher) SetLogger(logger *zerolog.Logger) {
+	d.logger = logger
+	d.next.SetLogger(logger)
+}
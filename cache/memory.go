@@ -0,0 +1,215 @@
+package cache
+
+import (
+	"sync"
+
+	"wumpgo.dev/wumpgo/objects"
+)
+
+var (
+	_ State   = (*Cache)(nil)
+	_ Updater = (*Cache)(nil)
+	_ backend = (*Cache)(nil)
+)
+
+type memberKey struct {
+	guild objects.Snowflake
+	user  objects.Snowflake
+}
+
+// Cache is an in-memory cache of Discord state fed by gateway events. It is
+// safe for concurrent use.
+type Cache struct {
+	// updateLock serializes updates, lookups don't take it.
+	updateLock sync.Mutex
+
+	meLock sync.RWMutex
+	me     *objects.User
+
+	guilds      *store[objects.Snowflake, *objects.Guild]
+	channels    *store[objects.Snowflake, *objects.Channel]
+	roles       *store[objects.Snowflake, *objects.Role]
+	members     *store[memberKey, *objects.GuildMember]
+	users       *store[objects.Snowflake, *objects.User]
+	presences   *store[memberKey, *objects.PresenceUpdate]
+	voiceStates *store[memberKey, *objects.VoiceState]
+}
+
+func New(opts ...Option) *Cache {
+	conf := newConfig(opts)
+	c := &Cache{}
+
+	if conf.entities&EntityGuilds != 0 {
+		c.guilds = newStore[objects.Snowflake, *objects.Guild](conf.limits[EntityGuilds])
+	}
+	if conf.entities&EntityChannels != 0 {
+		c.channels = newStore[objects.Snowflake, *objects.Channel](conf.limits[EntityChannels])
+	}
+	if conf.entities&EntityRoles != 0 {
+		c.roles = newStore[objects.Snowflake, *objects.Role](conf.limits[EntityRoles])
+	}
+	if conf.entities&EntityMembers != 0 {
+		c.members = newStore[memberKey, *objects.GuildMember](conf.limits[EntityMembers])
+	}
+	if conf.entities&EntityUsers != 0 {
+		c.users = newStore[objects.Snowflake, *objects.User](conf.limits[EntityUsers])
+	}
+	if conf.entities&EntityPresences != 0 {
+		c.presences = newStore[memberKey, *objects.PresenceUpdate](conf.limits[EntityPresences])
+	}
+	if conf.entities&EntityVoiceStates != 0 {
+		c.voiceStates = newStore[memberKey, *objects.VoiceState](conf.limits[EntityVoiceStates])
+	}
+
+	return c
+}
+
+func (c *Cache) Update(payload interface{}) error {
+	c.updateLock.Lock()
+	defer c.updateLock.Unlock()
+	return apply(c, payload)
+}
+
+func (c *Cache) Me() (*objects.User, bool) {
+	c.meLock.RLock()
+	defer c.meLock.RUnlock()
+	return c.me, c.me != nil
+}
+
+func (c *Cache) Guild(id objects.Snowflake) (*objects.Guild, bool) {
+	return c.guilds.get(id)
+}
+
+func (c *Cache) Guilds() []*objects.Guild {
+	return c.guilds.all()
+}
+
+func (c *Cache) Channel(id objects.Snowflake) (*objects.Channel, bool) {
+	return c.channels.get(id)
+}
+
+func (c *Cache) Channels(guild objects.Snowflake) []*objects.Channel {
+	return c.channels.guild(guild)
+}
+
+func (c *Cache) Role(id objects.Snowflake) (*objects.Role, bool) {
+	return c.roles.get(id)
+}
+
+func (c *Cache) Roles(guild objects.Snowflake) []*objects.Role {
+	return c.roles.guild(guild)
+}
+
+func (c *Cache) Member(guild, user objects.Snowflake) (*objects.GuildMember, bool) {
+	return c.members.get(memberKey{guild: guild, user: user})
+}
+
+func (c *Cache) Members(guild objects.Snowflake) []*objects.GuildMember {
+	return c.members.guild(guild)
+}
+
+func (c *Cache) User(id objects.Snowflake) (*objects.User, bool) {
+	return c.users.get(id)
+}
+
+func (c *Cache) Presence(guild, user objects.Snowflake) (*objects.PresenceUpdate, bool) {
+	return c.presences.get(memberKey{guild: guild, user: user})
+}
+
+func (c *Cache) VoiceState(guild, user objects.Snowflake) (*objects.VoiceState, bool) {
+	return c.voiceStates.get(memberKey{guild: guild, user: user})
+}
+
+func (c *Cache) VoiceStates(guild objects.Snowflake) []*objects.VoiceState {
+	return c.voiceStates.guild(guild)
+}
+
+func (c *Cache) guild(id objects.Snowflake) (*objects.Guild, bool) {
+	return c.guilds.get(id)
+}
+
+func (c *Cache) channel(id objects.Snowflake) (*objects.Channel, bool) {
+	return c.channels.get(id)
+}
+
+// The memory cache applies writes right away, there is nothing to batch.
+func (c *Cache) batch() batch {
+	return (*memoryBatch)(c)
+}
+
+type memoryBatch Cache
+
+func (b *memoryBatch) setMe(u *objects.User) {
+	b.meLock.Lock()
+	b.me = u
+	b.meLock.Unlock()
+}
+
+func (b *memoryBatch) setUser(u *objects.User) {
+	b.users.set(0, u.ID, u)
+}
+
+func (b *memoryBatch) setGuild(g *objects.Guild) {
+	b.guilds.set(g.ID, g.ID, g)
+}
+
+func (b *memoryBatch) deleteGuild(id objects.Snowflake) {
+	b.guilds.delete(id)
+	b.clearGuild(id)
+}
+
+func (b *memoryBatch) clearGuild(id objects.Snowflake) {
+	b.channels.deleteGuild(id)
+	b.roles.deleteGuild(id)
+	b.members.deleteGuild(id)
+	b.presences.deleteGuild(id)
+	b.voiceStates.deleteGuild(id)
+}
+
+func (b *memoryBatch) setChannel(ch *objects.Channel) {
+	b.channels.set(ch.GuildID, ch.ID, ch)
+}
+
+func (b *memoryBatch) deleteChannel(_, id objects.Snowflake) {
+	b.channels.delete(id)
+}
+
+func (b *memoryBatch) setRole(guild objects.Snowflake, r *objects.Role) {
+	b.roles.set(guild, r.ID, r)
+}
+
+func (b *memoryBatch) deleteRole(_, id objects.Snowflake) {
+	b.roles.delete(id)
+}
+
+func (b *memoryBatch) clearRoles(guild objects.Snowflake) {
+	b.roles.deleteGuild(guild)
+}
+
+func (b *memoryBatch) setMember(guild objects.Snowflake, m *objects.GuildMember) {
+	b.members.set(guild, memberKey{guild: guild, user: m.User.ID}, m)
+}
+
+func (b *memoryBatch) deleteMember(guild, user objects.Snowflake) {
+	b.members.delete(memberKey{guild: guild, user: user})
+}
+
+func (b *memoryBatch) setPresence(guild objects.Snowflake, p *objects.PresenceUpdate) {
+	b.presences.set(guild, memberKey{guild: guild, user: p.User.ID}, p)
+}
+
+func (b *memoryBatch) deletePresence(guild, user objects.Snowflake) {
+	b.presences.delete(memberKey{guild: guild, user: user})
+}
+
+func (b *memoryBatch) setVoiceState(vs *objects.VoiceState) {
+	b.voiceStates.set(vs.GuildID, memberKey{guild: vs.GuildID, user: vs.UserID}, vs)
+}
+
+func (b *memoryBatch) deleteVoiceState(guild, user objects.Snowflake) {
+	b.voiceStates.delete(memberKey{guild: guild, user: user})
+}
+
+func (b *memoryBatch) commit() error {
+	return nil
+}
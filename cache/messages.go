@@ -0,0 +1,297 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"time"
+
+	"wumpgo.dev/wumpgo/gateway/receiver"
+	"wumpgo.dev/wumpgo/objects"
+	"wumpgo.dev/wumpgo/rest"
+)
+
+// Events routed by a MessageCache through the receiver it is registered
+// with. Handlers for them take a *MessageUpdate, *MessageDelete or
+// *MessageDeleteBulk and are registered with On like any other handler, so
+// middleware, retries and the worker pool apply to them, e.g.
+//
+//	r.On(func(ctx context.Context, c rest.RESTClient, e *cache.MessageUpdate) {
+//		if e.Before != nil {
+//			log.Printf("%q was edited", e.Before.Content)
+//		}
+//	})
+const (
+	EventCachedMessageUpdate     = "CACHED_MESSAGE_UPDATE"
+	EventCachedMessageDelete     = "CACHED_MESSAGE_DELETE"
+	EventCachedMessageDeleteBulk = "CACHED_MESSAGE_DELETE_BULK"
+)
+
+func init() {
+	for _, err := range []error{
+		receiver.RegisterEvent[MessageUpdate](EventCachedMessageUpdate),
+		receiver.RegisterEvent[MessageDelete](EventCachedMessageDelete),
+		receiver.RegisterEvent[MessageDeleteBulk](EventCachedMessageDeleteBulk),
+	} {
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// MessageUpdate is a MESSAGE_UPDATE along with the message as it was before
+// it was edited.
+type MessageUpdate struct {
+	*objects.MessageUpdate
+	// Before is the message before the update, nil if it wasn't cached.
+	Before *objects.Message `json:"before"`
+}
+
+// MessageDelete is a MESSAGE_DELETE along with the deleted message.
+type MessageDelete struct {
+	*objects.MessageDelete
+	// Message is the deleted message, nil if it wasn't cached.
+	Message *objects.Message `json:"message"`
+}
+
+// MessageDeleteBulk is a MESSAGE_DELETE_BULK along with the deleted
+// messages.
+type MessageDeleteBulk struct {
+	*objects.MessageDeleteBulk
+	// Messages are the deleted messages that were cached.
+	Messages []*objects.Message `json:"messages"`
+}
+
+type MessageCacheConfig struct {
+	// MaxPerChannel is the number of messages kept per channel, the least
+	// recently used are evicted first. Defaults to 100.
+	MaxPerChannel int
+	// MaxChannels is the number of channels messages are kept for.
+	// Defaults to 1000.
+	MaxChannels int
+	// MaxAge is how long a message is kept after it was last created or
+	// updated. Expired messages are no longer returned and are evicted as
+	// new messages come in. Defaults to no limit.
+	MaxAge time.Duration
+}
+
+type cachedMessage struct {
+	message *objects.Message
+	at      time.Time
+}
+
+// MessageCache keeps recent messages, so that edits and deletes can be
+// reported along with what the message said before. It is safe for
+// concurrent use.
+type MessageCache struct {
+	maxPerChannel int
+	maxAge        time.Duration
+
+	lock       sync.Mutex
+	channels   *store[objects.Snowflake, *store[objects.Snowflake, cachedMessage]]
+	lastPruned time.Time
+
+	receiver receiver.Receiver
+}
+
+func NewMessageCache(conf MessageCacheConfig) *MessageCache {
+	if conf.MaxPerChannel <= 0 {
+		conf.MaxPerChannel = 100
+	}
+	if conf.MaxChannels <= 0 {
+		conf.MaxChannels = 1000
+	}
+
+	return &MessageCache{
+		maxPerChannel: conf.MaxPerChannel,
+		maxAge:        conf.MaxAge,
+		channels:      newStore[objects.Snowflake, *store[objects.Snowflake, cachedMessage]](conf.MaxChannels),
+		lastPruned:    time.Now(),
+	}
+}
+
+// Register records the messages routed by r and routes the
+// EventCachedMessage events to r for edits and deletes. It should be called
+// once, before any handler that reads the message cache is registered.
+//
+// The events are routed from within the handlers for the gateway events, a
+// worker pool with the Block policy must leave room in its queues for them.
+func (m *MessageCache) Register(r receiver.Receiver) error {
+	m.receiver = r
+
+	handlers := []receiver.HandlerFunc{
+		func(_ context.Context, _ rest.RESTClient, e *objects.MessageCreate) {
+			m.put(e.Message)
+		},
+		m.messageUpdate,
+		m.messageDelete,
+		m.messageDeleteBulk,
+		func(_ context.Context, _ rest.RESTClient, e *objects.ChannelDelete) {
+			if e.Channel != nil {
+				m.channels.delete(e.Channel.ID)
+			}
+		},
+		func(_ context.Context, _ rest.RESTClient, e *objects.ThreadDelete) {
+			if e.Channel != nil {
+				m.channels.delete(e.Channel.ID)
+			}
+		},
+		func(_ context.Context, _ rest.RESTClient, e *objects.GuildDelete) {
+			if e.Guild != nil && !e.Unavailable {
+				m.channels.deleteGuild(e.ID)
+			}
+		},
+	}
+
+	for _, h := range handlers {
+		if _, err := r.On(h); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Message returns a cached message.
+func (m *MessageCache) Message(channelID, id objects.Snowflake) (*objects.Message, bool) {
+	msgs, ok := m.channels.get(channelID)
+	if !ok {
+		return nil, false
+	}
+
+	cm, ok := msgs.get(id)
+	if !ok || m.expired(cm) {
+		return nil, false
+	}
+
+	return cm.message, true
+}
+
+// Messages returns the cached messages of a channel, most recently used
+// first.
+func (m *MessageCache) Messages(channelID objects.Snowflake) []*objects.Message {
+	msgs, ok := m.channels.get(channelID)
+	if !ok {
+		return nil
+	}
+
+	cached := msgs.all()
+	out := make([]*objects.Message, 0, len(cached))
+	for _, cm := range cached {
+		if !m.expired(cm) {
+			out = append(out, cm.message)
+		}
+	}
+	return out
+}
+
+func (m *MessageCache) expired(cm cachedMessage) bool {
+	return m.maxAge > 0 && time.Since(cm.at) > m.maxAge
+}
+
+func (m *MessageCache) put(msg *objects.Message) {
+	if msg == nil {
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := time.Now()
+	if m.maxAge > 0 && now.Sub(m.lastPruned) > m.maxAge {
+		m.prune()
+		m.lastPruned = now
+	}
+
+	msgs, ok := m.channels.get(msg.ChannelID)
+	if !ok {
+		msgs = newStore[objects.Snowflake, cachedMessage](m.maxPerChannel)
+		m.channels.set(msg.GuildID, msg.ChannelID, msgs)
+	}
+
+	msgs.set(0, msg.ID, cachedMessage{message: msg, at: now})
+}
+
+// prune evicts expired messages and the channels left without messages. It
+// must be called with the lock held, so that no message is added to a
+// channel that is being evicted.
+func (m *MessageCache) prune() {
+	m.channels.deleteFunc(func(msgs *store[objects.Snowflake, cachedMessage]) bool {
+		msgs.deleteFunc(m.expired)
+		return msgs.len() == 0
+	})
+}
+
+// take removes a message from the cache and returns it.
+func (m *MessageCache) take(channelID, id objects.Snowflake) *objects.Message {
+	msg, ok := m.Message(channelID, id)
+	if !ok {
+		return nil
+	}
+
+	if msgs, ok := m.channels.get(channelID); ok {
+		msgs.delete(id)
+	}
+
+	return msg
+}
+
+func (m *MessageCache) messageUpdate(_ context.Context, _ rest.RESTClient, e *objects.MessageUpdate) error {
+	if e.Message == nil {
+		return nil
+	}
+
+	before, _ := m.Message(e.ChannelID, e.ID)
+
+	after := e.Message
+	if after.Author == nil && before != nil {
+		// Partial updates, such as embeds being resolved, only carry what
+		// changed.
+		after = mergeMessage(before, after)
+	}
+	m.put(after)
+
+	return m.route(EventCachedMessageUpdate, &MessageUpdate{MessageUpdate: e, Before: before})
+}
+
+func (m *MessageCache) messageDelete(_ context.Context, _ rest.RESTClient, e *objects.MessageDelete) error {
+	msg := m.take(e.ChannelID, e.ID)
+	return m.route(EventCachedMessageDelete, &MessageDelete{MessageDelete: e, Message: msg})
+}
+
+func (m *MessageCache) messageDeleteBulk(_ context.Context, _ rest.RESTClient, e *objects.MessageDeleteBulk) error {
+	var msgs []*objects.Message
+	for _, id := range e.IDs {
+		if msg := m.take(e.ChannelID, id); msg != nil {
+			msgs = append(msgs, msg)
+		}
+	}
+
+	return m.route(EventCachedMessageDeleteBulk, &MessageDeleteBulk{MessageDeleteBulk: e, Messages: msgs})
+}
+
+func (m *MessageCache) route(event string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return m.receiver.Route(event, data)
+}
+
+// mergeMessage applies a partial update to a copy of a cached message.
+// Fields with zero values are missing from the update, except for empty
+// slices, which clear the field.
+func mergeMessage(before, partial *objects.Message) *objects.Message {
+	merged := *before
+
+	dst := reflect.ValueOf(&merged).Elem()
+	src := reflect.ValueOf(partial).Elem()
+	for i := 0; i < src.NumField(); i++ {
+		if f := src.Field(i); !f.IsZero() {
+			dst.Field(i).Set(f)
+		}
+	}
+
+	return &merged
+}
@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+
+	"wumpgo.dev/wumpgo/objects"
+	"wumpgo.dev/wumpgo/objects/permissions"
+)
+
+// ErrNotCached is returned when state needed to answer a query is missing
+// from the cache.
+var ErrNotCached = errors.New("not cached")
+
+// GuildPermissions computes a member's guild wide permissions from cached
+// state.
+func GuildPermissions(s State, guildID, userID objects.Snowflake) (permissions.PermissionBit, error) {
+	guild, member, err := guildAndMember(s, guildID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return objects.BasePermissions(guild, s.Roles(guildID), member), nil
+}
+
+// ChannelPermissions computes a member's permissions in a channel or thread
+// from cached state.
+func ChannelPermissions(s State, channelID, userID objects.Snowflake) (permissions.PermissionBit, error) {
+	channel, ok := s.Channel(channelID)
+	if !ok {
+		return 0, fmt.Errorf("channel %s: %w", channelID, ErrNotCached)
+	}
+
+	guild, member, err := guildAndMember(s, channel.GuildID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	var parent *objects.Channel
+	if channel.IsThread() {
+		if parent, ok = s.Channel(channel.ParentID); !ok {
+			return 0, fmt.Errorf("parent channel %s: %w", channel.ParentID, ErrNotCached)
+		}
+	}
+
+	return objects.ChannelPermissions(guild, s.Roles(guild.ID), member, channel, parent), nil
+}
+
+func guildAndMember(s State, guildID, userID objects.Snowflake) (*objects.Guild, *objects.GuildMember, error) {
+	guild, ok := s.Guild(guildID)
+	if !ok {
+		return nil, nil, fmt.Errorf("guild %s: %w", guildID, ErrNotCached)
+	}
+
+	member, ok := s.Member(guildID, userID)
+	if !ok {
+		return nil, nil, fmt.Errorf("member %s of guild %s: %w", userID, guildID, ErrNotCached)
+	}
+
+	return guild, member, nil
+}
@@ -0,0 +1,377 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+	"wumpgo.dev/wumpgo/objects"
+)
+
+var (
+	_ State   = (*RedisCache)(nil)
+	_ Updater = (*RedisCache)(nil)
+	_ backend = (*RedisCache)(nil)
+)
+
+// RedisCache keeps state in Redis, so that it can be written by the service
+// running the gateway, e.g. through a Dispatcher, and read by any number of
+// workers.
+//
+// Entities are stored as JSON in hashes, one per guild and kind of entity,
+// e.g. "<prefix>guild:<id>:members", so that a guild's state can be read
+// and invalidated in a few commands. Updates of a single event are applied
+// in one transaction and updates are serialized, so a single process should
+// call Update. Limits are not supported.
+type RedisCache struct {
+	rdb      *redis.Client
+	prefix   string
+	entities Entity
+	timeout  time.Duration
+	log      zerolog.Logger
+
+	// updateLock serializes updates, lookups don't take it.
+	updateLock sync.Mutex
+}
+
+// NewRedisCache creates a cache whose keys are prefixed with prefix, e.g.
+// "wumpgo:cache:".
+func NewRedisCache(rdb *redis.Client, prefix string, opts ...Option) *RedisCache {
+	conf := newConfig(opts)
+
+	return &RedisCache{
+		rdb:      rdb,
+		prefix:   prefix,
+		entities: conf.entities,
+		timeout:  conf.timeout,
+		log:      conf.log,
+	}
+}
+
+func (r *RedisCache) key(parts ...string) string {
+	return r.prefix + strings.Join(parts, ":")
+}
+
+func (r *RedisCache) guildKey(guild objects.Snowflake, kind string) string {
+	return r.key("guild", guild.String(), kind)
+}
+
+func (r *RedisCache) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), r.timeout)
+}
+
+func (r *RedisCache) Update(payload interface{}) error {
+	r.updateLock.Lock()
+	defer r.updateLock.Unlock()
+	return apply(r, payload)
+}
+
+func (r *RedisCache) Me() (*objects.User, bool) {
+	ctx, cancel := r.context()
+	defer cancel()
+
+	var u objects.User
+	ok := r.decode(r.rdb.Get(ctx, r.key("me")), &u)
+	return &u, ok
+}
+
+func (r *RedisCache) Guild(id objects.Snowflake) (*objects.Guild, bool) {
+	return r.guild(id)
+}
+
+func (r *RedisCache) Guilds() []*objects.Guild {
+	return hvals[objects.Guild](r, r.key("guilds"))
+}
+
+func (r *RedisCache) Channel(id objects.Snowflake) (*objects.Channel, bool) {
+	return r.channel(id)
+}
+
+func (r *RedisCache) Channels(guild objects.Snowflake) []*objects.Channel {
+	return hvals[objects.Channel](r, r.guildKey(guild, "channels"))
+}
+
+func (r *RedisCache) Role(id objects.Snowflake) (*objects.Role, bool) {
+	return indexed[objects.Role](r, "roles", id)
+}
+
+func (r *RedisCache) Roles(guild objects.Snowflake) []*objects.Role {
+	return hvals[objects.Role](r, r.guildKey(guild, "roles"))
+}
+
+func (r *RedisCache) Member(guild, user objects.Snowflake) (*objects.GuildMember, bool) {
+	return hget[objects.GuildMember](r, r.guildKey(guild, "members"), user)
+}
+
+func (r *RedisCache) Members(guild objects.Snowflake) []*objects.GuildMember {
+	return hvals[objects.GuildMember](r, r.guildKey(guild, "members"))
+}
+
+func (r *RedisCache) User(id objects.Snowflake) (*objects.User, bool) {
+	return hget[objects.User](r, r.key("users"), id)
+}
+
+func (r *RedisCache) Presence(guild, user objects.Snowflake) (*objects.PresenceUpdate, bool) {
+	return hget[objects.PresenceUpdate](r, r.guildKey(guild, "presences"), user)
+}
+
+func (r *RedisCache) VoiceState(guild, user objects.Snowflake) (*objects.VoiceState, bool) {
+	return hget[objects.VoiceState](r, r.guildKey(guild, "voice_states"), user)
+}
+
+func (r *RedisCache) VoiceStates(guild objects.Snowflake) []*objects.VoiceState {
+	return hvals[objects.VoiceState](r, r.guildKey(guild, "voice_states"))
+}
+
+func (r *RedisCache) guild(id objects.Snowflake) (*objects.Guild, bool) {
+	return hget[objects.Guild](r, r.key("guilds"), id)
+}
+
+func (r *RedisCache) channel(id objects.Snowflake) (*objects.Channel, bool) {
+	return indexed[objects.Channel](r, "channels", id)
+}
+
+// decode decodes the JSON result of cmd into v. Errors other than a missing
+// key are logged.
+func (r *RedisCache) decode(cmd *redis.StringCmd, v interface{}) bool {
+	b, err := cmd.Bytes()
+	if err == nil {
+		err = json.Unmarshal(b, v)
+	}
+
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			r.log.Warn().Err(err).Str("cmd", cmd.FullName()).Msg("failed to read from cache")
+		}
+		return false
+	}
+
+	return true
+}
+
+func hget[T any](r *RedisCache, key string, field objects.Snowflake) (*T, bool) {
+	ctx, cancel := r.context()
+	defer cancel()
+
+	var v T
+	ok := r.decode(r.rdb.HGet(ctx, key, field.String()), &v)
+	return &v, ok
+}
+
+func hvals[T any](r *RedisCache, key string) []*T {
+	ctx, cancel := r.context()
+	defer cancel()
+
+	vals, err := r.rdb.HVals(ctx, key).Result()
+	if err != nil {
+		r.log.Warn().Err(err).Str("key", key).Msg("failed to read from cache")
+		return nil
+	}
+
+	out := make([]*T, 0, len(vals))
+	for _, val := range vals {
+		var v T
+		if err := json.Unmarshal([]byte(val), &v); err != nil {
+			r.log.Warn().Err(err).Str("key", key).Msg("failed to decode cached value")
+			continue
+		}
+		out = append(out, &v)
+	}
+	return out
+}
+
+// indexed looks up an entity stored in a guild hash by its ID alone, through
+// the "<prefix><kind>" hash mapping IDs to guilds.
+func indexed[T any](r *RedisCache, kind string, id objects.Snowflake) (*T, bool) {
+	ctx, cancel := r.context()
+	defer cancel()
+
+	guild, err := r.rdb.HGet(ctx, r.key(kind), id.String()).Result()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			r.log.Warn().Err(err).Str("kind", kind).Msg("failed to read from cache")
+		}
+		return nil, false
+	}
+
+	var v T
+	ok := r.decode(r.rdb.HGet(ctx, r.key("guild", guild, kind), id.String()), &v)
+	return &v, ok
+}
+
+// batch queues writes in a transaction. Queued commands don't use their
+// context, the timeout only applies once the transaction is sent on commit.
+func (r *RedisCache) batch() batch {
+	return &redisBatch{
+		RedisCache: r,
+		ctx:        context.Background(),
+		pipe:       r.rdb.TxPipeline(),
+	}
+}
+
+type redisBatch struct {
+	*RedisCache
+	ctx  context.Context
+	pipe redis.Pipeliner
+	err  error
+}
+
+func (b *redisBatch) has(e Entity) bool {
+	return b.entities&e != 0
+}
+
+func (b *redisBatch) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+func (b *redisBatch) hset(key string, field objects.Snowflake, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		b.fail(err)
+		return
+	}
+	b.pipe.HSet(b.ctx, key, field.String(), data)
+}
+
+func (b *redisBatch) setMe(u *objects.User) {
+	data, err := json.Marshal(u)
+	if err != nil {
+		b.fail(err)
+		return
+	}
+	b.pipe.Set(b.ctx, b.key("me"), data, 0)
+}
+
+func (b *redisBatch) setUser(u *objects.User) {
+	if b.has(EntityUsers) {
+		b.hset(b.key("users"), u.ID, u)
+	}
+}
+
+func (b *redisBatch) setGuild(g *objects.Guild) {
+	if b.has(EntityGuilds) {
+		b.hset(b.key("guilds"), g.ID, g)
+	}
+}
+
+func (b *redisBatch) deleteGuild(id objects.Snowflake) {
+	b.pipe.HDel(b.ctx, b.key("guilds"), id.String())
+	b.clearGuild(id)
+}
+
+func (b *redisBatch) clearGuild(id objects.Snowflake) {
+	b.clearIndexed(id, "channels")
+	b.clearIndexed(id, "roles")
+	b.pipe.Del(b.ctx,
+		b.guildKey(id, "members"),
+		b.guildKey(id, "presences"),
+		b.guildKey(id, "voice_states"),
+	)
+}
+
+// clearIndexScript removes a guild hash and the entries of its fields in the
+// ID index.
+//
+// KEYS: guild hash, index hash
+var clearIndexScript = redis.NewScript(`
+local ids = redis.call('HKEYS', KEYS[1])
+for i = 1, #ids, 1000 do
+	redis.call('HDEL', KEYS[2], unpack(ids, i, math.min(i + 999, #ids)))
+end
+redis.call('DEL', KEYS[1])
+return #ids
+`)
+
+// clearIndexed removes a guild hash and its entries in the ID index, within
+// the transaction.
+func (b *redisBatch) clearIndexed(guild objects.Snowflake, kind string) {
+	clearIndexScript.Eval(b.ctx, b.pipe, []string{b.guildKey(guild, kind), b.key(kind)})
+}
+
+func (b *redisBatch) setIndexed(guild objects.Snowflake, kind string, id objects.Snowflake, v interface{}) {
+	b.hset(b.guildKey(guild, kind), id, v)
+	b.pipe.HSet(b.ctx, b.key(kind), id.String(), guild.String())
+}
+
+func (b *redisBatch) deleteIndexed(guild objects.Snowflake, kind string, id objects.Snowflake) {
+	b.pipe.HDel(b.ctx, b.guildKey(guild, kind), id.String())
+	b.pipe.HDel(b.ctx, b.key(kind), id.String())
+}
+
+func (b *redisBatch) setChannel(ch *objects.Channel) {
+	if b.has(EntityChannels) {
+		b.setIndexed(ch.GuildID, "channels", ch.ID, ch)
+	}
+}
+
+func (b *redisBatch) deleteChannel(guild, id objects.Snowflake) {
+	b.deleteIndexed(guild, "channels", id)
+}
+
+func (b *redisBatch) setRole(guild objects.Snowflake, r *objects.Role) {
+	if b.has(EntityRoles) {
+		b.setIndexed(guild, "roles", r.ID, r)
+	}
+}
+
+func (b *redisBatch) deleteRole(guild, id objects.Snowflake) {
+	b.deleteIndexed(guild, "roles", id)
+}
+
+func (b *redisBatch) clearRoles(guild objects.Snowflake) {
+	b.clearIndexed(guild, "roles")
+}
+
+func (b *redisBatch) setMember(guild objects.Snowflake, m *objects.GuildMember) {
+	if b.has(EntityMembers) {
+		b.hset(b.guildKey(guild, "members"), m.User.ID, m)
+	}
+}
+
+func (b *redisBatch) deleteMember(guild, user objects.Snowflake) {
+	b.pipe.HDel(b.ctx, b.guildKey(guild, "members"), user.String())
+}
+
+func (b *redisBatch) setPresence(guild objects.Snowflake, p *objects.PresenceUpdate) {
+	if b.has(EntityPresences) {
+		b.hset(b.guildKey(guild, "presences"), p.User.ID, p)
+	}
+}
+
+func (b *redisBatch) deletePresence(guild, user objects.Snowflake) {
+	b.pipe.HDel(b.ctx, b.guildKey(guild, "presences"), user.String())
+}
+
+func (b *redisBatch) setVoiceState(vs *objects.VoiceState) {
+	if b.has(EntityVoiceStates) {
+		b.hset(b.guildKey(vs.GuildID, "voice_states"), vs.UserID, vs)
+	}
+}
+
+func (b *redisBatch) deleteVoiceState(guild, user objects.Snowflake) {
+	b.pipe.HDel(b.ctx, b.guildKey(guild, "voice_states"), user.String())
+}
+
+func (b *redisBatch) commit() error {
+	if b.err != nil {
+		b.pipe.Discard()
+		return b.err
+	}
+
+	if b.pipe.Len() == 0 {
+		return nil
+	}
+
+	ctx, cancel := b.context()
+	defer cancel()
+
+	_, err := b.pipe.Exec(ctx)
+	return err
+}
@@ -0,0 +1,195 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+
+	"wumpgo.dev/wumpgo/objects"
+)
+
+type entry[K comparable, V any] struct {
+	key   K
+	guild objects.Snowflake
+	value V
+}
+
+// store is a least recently used map of entities, indexed by the guild they
+// belong to. A nil store caches nothing.
+type store[K comparable, V any] struct {
+	lock   sync.Mutex
+	limit  int
+	items  map[K]*list.Element
+	order  *list.List
+	guilds map[objects.Snowflake]map[K]struct{}
+}
+
+func newStore[K comparable, V any](limit int) *store[K, V] {
+	return &store[K, V]{
+		limit:  limit,
+		items:  make(map[K]*list.Element),
+		order:  list.New(),
+		guilds: make(map[objects.Snowflake]map[K]struct{}),
+	}
+}
+
+func (s *store[K, V]) get(key K) (V, bool) {
+	var zero V
+	if s == nil {
+		return zero, false
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	el, ok := s.items[key]
+	if !ok {
+		return zero, false
+	}
+
+	s.order.MoveToFront(el)
+	return el.Value.(*entry[K, V]).value, true
+}
+
+func (s *store[K, V]) set(guild objects.Snowflake, key K, value V) {
+	if s == nil {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if el, ok := s.items[key]; ok {
+		e := el.Value.(*entry[K, V])
+		if e.guild != guild {
+			s.unindex(e)
+			e.guild = guild
+			s.index(e)
+		}
+		e.value = value
+		s.order.MoveToFront(el)
+		return
+	}
+
+	e := &entry[K, V]{key: key, guild: guild, value: value}
+	s.items[key] = s.order.PushFront(e)
+	s.index(e)
+
+	for s.limit > 0 && s.order.Len() > s.limit {
+		s.remove(s.order.Back())
+	}
+}
+
+func (s *store[K, V]) delete(key K) {
+	if s == nil {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if el, ok := s.items[key]; ok {
+		s.remove(el)
+	}
+}
+
+func (s *store[K, V]) deleteGuild(guild objects.Snowflake) {
+	if s == nil {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for key := range s.guilds[guild] {
+		s.remove(s.items[key])
+	}
+}
+
+func (s *store[K, V]) guild(guild objects.Snowflake) []V {
+	if s == nil {
+		return nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	values := make([]V, 0, len(s.guilds[guild]))
+	for key := range s.guilds[guild] {
+		values = append(values, s.items[key].Value.(*entry[K, V]).value)
+	}
+	return values
+}
+
+func (s *store[K, V]) all() []V {
+	if s == nil {
+		return nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	values := make([]V, 0, len(s.items))
+	for el := s.order.Front(); el != nil; el = el.Next() {
+		values = append(values, el.Value.(*entry[K, V]).value)
+	}
+	return values
+}
+
+// deleteFunc removes every entry f returns true for.
+func (s *store[K, V]) deleteFunc(f func(V) bool) {
+	if s == nil {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for el := s.order.Front(); el != nil; {
+		next := el.Next()
+		if f(el.Value.(*entry[K, V]).value) {
+			s.remove(el)
+		}
+		el = next
+	}
+}
+
+func (s *store[K, V]) len() int {
+	if s == nil {
+		return 0
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.order.Len()
+}
+
+func (s *store[K, V]) remove(el *list.Element) {
+	e := s.order.Remove(el).(*entry[K, V])
+	delete(s.items, e.key)
+	s.unindex(e)
+}
+
+func (s *store[K, V]) index(e *entry[K, V]) {
+	if e.guild == 0 {
+		return
+	}
+
+	keys, ok := s.guilds[e.guild]
+	if !ok {
+		keys = make(map[K]struct{})
+		s.guilds[e.guild] = keys
+	}
+	keys[e.key] = struct{}{}
+}
+
+func (s *store[K, V]) unindex(e *entry[K, V]) {
+	keys, ok := s.guilds[e.guild]
+	if !ok {
+		return
+	}
+
+	delete(keys, e.key)
+	if len(keys) == 0 {
+		delete(s.guilds, e.guild)
+	}
+}
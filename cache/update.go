@@ -0,0 +1,290 @@
+package cache
+
+import (
+	"wumpgo.dev/wumpgo/objects"
+)
+
+// backend is where a cache keeps its state.
+type backend interface {
+	guild(id objects.Snowflake) (*objects.Guild, bool)
+	channel(id objects.Snowflake) (*objects.Channel, bool)
+	// batch starts a set of writes that are applied together on commit.
+	batch() batch
+}
+
+type batch interface {
+	setMe(u *objects.User)
+	setUser(u *objects.User)
+	setGuild(g *objects.Guild)
+	// deleteGuild removes a guild and everything in it.
+	deleteGuild(id objects.Snowflake)
+	// clearGuild removes everything in a guild, but not the guild itself.
+	clearGuild(id objects.Snowflake)
+	setChannel(ch *objects.Channel)
+	deleteChannel(guild, id objects.Snowflake)
+	setRole(guild objects.Snowflake, r *objects.Role)
+	deleteRole(guild, id objects.Snowflake)
+	clearRoles(guild objects.Snowflake)
+	setMember(guild objects.Snowflake, m *objects.GuildMember)
+	deleteMember(guild, user objects.Snowflake)
+	setPresence(guild objects.Snowflake, p *objects.PresenceUpdate)
+	deletePresence(guild, user objects.Snowflake)
+	setVoiceState(vs *objects.VoiceState)
+	deleteVoiceState(guild, user objects.Snowflake)
+	commit() error
+}
+
+// apply applies a gateway event to a backend. payload is a decoded event,
+// e.g. *objects.GuildCreate. Events that don't affect the cache are ignored.
+func apply(be backend, payload interface{}) error {
+	b := be.batch()
+
+	switch e := payload.(type) {
+	case *objects.Ready:
+		setMe(b, e.User)
+		for _, g := range e.Guilds {
+			if _, ok := be.guild(g.ID); !ok {
+				b.setGuild(g)
+			}
+		}
+	case *objects.UserUpdate:
+		setMe(b, e.User)
+
+	case *objects.GuildCreate:
+		guildCreate(be, b, e.Guild)
+	case *objects.GuildUpdate:
+		guildUpdate(be, b, e.Guild)
+	case *objects.GuildDelete:
+		guildDelete(be, b, e.Guild)
+	case *objects.GuildEmojisUpdate:
+		if g, ok := be.guild(e.GuildID); ok {
+			cp := *g
+			cp.Emojis = e.Emojis
+			b.setGuild(&cp)
+		}
+	case *objects.GuildStickersUpdate:
+		if g, ok := be.guild(e.GuildID); ok {
+			cp := *g
+			cp.Stickers = e.Stickers
+			b.setGuild(&cp)
+		}
+
+	case *objects.ChannelCreate:
+		channelEvent(b, e.Channel)
+	case *objects.ChannelUpdate:
+		channelEvent(b, e.Channel)
+	case *objects.ChannelDelete:
+		if e.Channel != nil {
+			b.deleteChannel(e.Channel.GuildID, e.Channel.ID)
+		}
+	case *objects.ChannelPinsUpdate:
+		if ch, ok := be.channel(e.ChannelID); ok {
+			cp := *ch
+			cp.LastPinTimestamp = e.LastPinTimestamp
+			b.setChannel(&cp)
+		}
+
+	case *objects.ThreadCreate:
+		channelEvent(b, e.Channel)
+	case *objects.ThreadUpdate:
+		channelEvent(b, e.Channel)
+	case *objects.ThreadDelete:
+		if e.Channel != nil {
+			b.deleteChannel(e.Channel.GuildID, e.Channel.ID)
+		}
+	case *objects.ThreadListSync:
+		for _, t := range e.Threads {
+			setChannel(b, e.GuildID, t)
+		}
+
+	case *objects.GuildRoleCreate:
+		setRole(b, e.GuildID, e.Role)
+	case *objects.GuildRoleUpdate:
+		setRole(b, e.GuildID, e.Role)
+	case *objects.GuildRoleDelete:
+		b.deleteRole(e.GuildID, e.RoleID)
+
+	case *objects.GuildMemberAdd:
+		setMember(b, e.GuildID, e.GuildMember)
+	case *objects.GuildMemberUpdate:
+		setMember(b, e.GuildID, e.GuildMember)
+	case *objects.GuildMemberRemove:
+		if e.User != nil {
+			b.deleteMember(e.GuildID, e.User.ID)
+			b.deletePresence(e.GuildID, e.User.ID)
+			b.deleteVoiceState(e.GuildID, e.User.ID)
+		}
+	case *objects.GuildMembersChunk:
+		for _, m := range e.Members {
+			setMember(b, e.GuildID, m)
+		}
+
+	case *objects.PresenceUpdate:
+		setPresence(b, e.GuildID, e)
+	case *objects.VoiceStateUpdate:
+		setVoiceState(b, e.VoiceState)
+
+	default:
+		return nil
+	}
+
+	return b.commit()
+}
+
+func setMe(b batch, u *objects.User) {
+	if u == nil {
+		return
+	}
+
+	b.setMe(u)
+	b.setUser(u)
+}
+
+func guildCreate(be backend, b batch, g *objects.Guild) {
+	if g == nil {
+		return
+	}
+
+	if g.Unavailable {
+		b.setGuild(g)
+		return
+	}
+
+	// A guild that becomes available again sends the full guild state.
+	b.clearGuild(g.ID)
+	guildUpdate(be, b, g)
+
+	for _, ch := range g.Channels {
+		setChannel(b, g.ID, ch)
+	}
+	for _, t := range g.Threads {
+		setChannel(b, g.ID, t)
+	}
+	for _, m := range g.Members {
+		setMember(b, g.ID, m)
+	}
+	for _, p := range g.Presences {
+		setPresence(b, g.ID, p)
+	}
+	for _, vs := range g.VoiceStates {
+		cp := *vs
+		cp.GuildID = g.ID
+		setVoiceState(b, &cp)
+	}
+}
+
+func guildUpdate(be backend, b batch, g *objects.Guild) {
+	if g == nil {
+		return
+	}
+
+	if g.Roles != nil {
+		b.clearRoles(g.ID)
+		for _, r := range g.Roles {
+			setRole(b, g.ID, r)
+		}
+	}
+
+	cp := *g
+	cp.Roles = nil
+	cp.Channels = nil
+	cp.Threads = nil
+	cp.Members = nil
+	cp.Presences = nil
+	cp.VoiceStates = nil
+
+	if old, ok := be.guild(g.ID); ok && !old.Unavailable {
+		// GUILD_UPDATE doesn't carry the fields only sent in GUILD_CREATE.
+		if cp.JoinedAt.IsZero() {
+			cp.JoinedAt = old.JoinedAt
+		}
+		if cp.MemberCount == 0 {
+			cp.MemberCount = old.MemberCount
+		}
+		cp.Large = cp.Large || old.Large
+	}
+
+	b.setGuild(&cp)
+}
+
+func guildDelete(be backend, b batch, g *objects.Guild) {
+	if g == nil {
+		return
+	}
+
+	if !g.Unavailable {
+		b.deleteGuild(g.ID)
+		return
+	}
+
+	// An outage, the guild is still there and will be sent again.
+	if old, ok := be.guild(g.ID); ok {
+		cp := *old
+		cp.Unavailable = true
+		b.setGuild(&cp)
+	}
+}
+
+func channelEvent(b batch, ch *objects.Channel) {
+	if ch != nil {
+		setChannel(b, ch.GuildID, ch)
+	}
+}
+
+func setChannel(b batch, guild objects.Snowflake, ch *objects.Channel) {
+	if ch == nil {
+		return
+	}
+
+	if ch.GuildID != guild {
+		cp := *ch
+		cp.GuildID = guild
+		ch = &cp
+	}
+
+	b.setChannel(ch)
+}
+
+func setRole(b batch, guild objects.Snowflake, r *objects.Role) {
+	if r != nil {
+		b.setRole(guild, r)
+	}
+}
+
+func setMember(b batch, guild objects.Snowflake, m *objects.GuildMember) {
+	if m == nil || m.User == nil {
+		return
+	}
+
+	b.setMember(guild, m)
+	b.setUser(m.User)
+}
+
+func setPresence(b batch, guild objects.Snowflake, p *objects.PresenceUpdate) {
+	if p == nil || p.User == nil {
+		return
+	}
+
+	// Presences usually only carry the user's ID.
+	if p.User.Username != "" {
+		b.setUser(p.User)
+	}
+
+	b.setPresence(guild, p)
+}
+
+func setVoiceState(b batch, vs *objects.VoiceState) {
+	if vs == nil {
+		return
+	}
+
+	if vs.ChannelID == 0 {
+		b.deleteVoiceState(vs.GuildID, vs.UserID)
+	} else {
+		b.setVoiceState(vs)
+	}
+
+	if vs.Member != nil && vs.GuildID != 0 {
+		setMember(b, vs.GuildID, vs.Member)
+	}
+}
@@ -34,19 +34,35 @@ func main() {
 func generate(sourceTypes []string) error {
 	f := jen.NewFile(os.Getenv("GOPACKAGE"))
 	f.PackageComment("Code generated by events generator, DO NOT EDIT.")
+
+	f.Const().DefsFunc(func(g *jen.Group) {
+		for _, sourceType := range sourceTypes {
+			g.Id("Event" + sourceType).Op("=").Lit(strings.ToUpper(pascalToSnakeCase(sourceType)))
+		}
+	})
+
+	f.Var().Id("eventTypes").Op("=").Map(jen.String()).Qual("reflect", "Type").Values(jen.DictFunc(func(d jen.Dict) {
+		for _, sourceType := range sourceTypes {
+			d[jen.Id("Event"+sourceType)] = jen.Qual("reflect", "TypeOf").Call(jen.Qual("wumpgo.dev/wumpgo/objects", sourceType).Values())
+		}
+	}))
+
 	f.Func().Id("eventHandlerToEvent").Params(jen.Id("h").Id("HandlerFunc")).
 		Params(jen.Id("EventHandlerIface"), jen.String(), jen.Error()).
 		Block(jen.Switch(jen.Id("v").Op(":=").Id("h").Assert(jen.Type())).BlockFunc(
 			func(g *jen.Group) {
 				for _, sourceType := range sourceTypes {
+					params := []jen.Code{
+						jen.Qual("context", "Context"),
+						jen.Qual("wumpgo.dev/wumpgo/rest", "RESTClient"),
+						jen.Op("*").Qual("wumpgo.dev/wumpgo/objects", sourceType),
+					}
 					g.Case(
-						jen.Func().
-							Params(
-								jen.Qual("context", "Context"),
-								jen.Qual("wumpgo.dev/wumpgo/rest", "RESTClient"),
-								jen.Op("*").Qual("wumpgo.dev/wumpgo/objects", sourceType),
-							),
+						jen.Func().Params(params...),
 					).Block(jen.Return(jen.Id("newHandler").Call(jen.Id("v")), jen.Lit(pascalToSnakeCase(sourceType)), jen.Nil()))
+					g.Case(
+						jen.Func().Params(params...).Error(),
+					).Block(jen.Return(jen.Id("newErrorHandler").Call(jen.Id("v")), jen.Lit(pascalToSnakeCase(sourceType)), jen.Nil()))
 				}
 				g.Default().Block(
 					jen.Return(jen.Nil(), jen.Lit("invalid"), jen.Qual("fmt", "Errorf").Call(jen.Lit("invalid handler func"))),
@@ -54,6 +70,33 @@ func generate(sourceTypes []string) error {
 			},
 		))
 
+	f.Comment("EventHandlers registers handlers for a single event type without going")
+	f.Comment("through On's type switch. Every Receiver implements it.")
+	f.Type().Id("EventHandlers").InterfaceFunc(func(g *jen.Group) {
+		for _, sourceType := range sourceTypes {
+			g.Id("On" + sourceType).Params(jen.Id("h").Func().Params(
+				jen.Qual("context", "Context"),
+				jen.Qual("wumpgo.dev/wumpgo/rest", "RESTClient"),
+				jen.Op("*").Qual("wumpgo.dev/wumpgo/objects", sourceType),
+			)).Op("*").Id("Registration")
+		}
+	})
+
+	for _, sourceType := range sourceTypes {
+		eventName := strings.ToUpper(pascalToSnakeCase(sourceType))
+		f.Commentf("On%s registers a handler for %s events.", sourceType, eventName)
+		f.Func().Params(jen.Id("e").Op("*").Id("eventRouter")).Id("On" + sourceType).
+			Params(jen.Id("h").Func().Params(
+				jen.Qual("context", "Context"),
+				jen.Qual("wumpgo.dev/wumpgo/rest", "RESTClient"),
+				jen.Op("*").Qual("wumpgo.dev/wumpgo/objects", sourceType),
+			)).
+			Op("*").Id("Registration").
+			Block(jen.Return(jen.Id("e").Dot("add").Call(
+				jen.Lit(pascalToSnakeCase(sourceType)), jen.Id("newHandler").Call(jen.Id("h")), jen.False(),
+			)))
+	}
+
 	filename := os.Getenv("GOFILE")
 	ext := filepath.Ext(filename)
 	baseName := filename[0 : len(filename)-len(ext)]
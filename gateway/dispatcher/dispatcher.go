@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	"github.com/rs/zerolog"
+	"wumpgo.dev/wumpgo/gateway/receiver"
 )
 
 type Dispatcher interface {
@@ -11,6 +12,25 @@ type Dispatcher interface {
 	SetLogger(l *zerolog.Logger)
 }
 
+// Metadata describes where a dispatched event came from.
+type Metadata = receiver.EventMetadata
+
+// MetadataDispatcher is implemented by dispatchers that can forward event
+// metadata, such as the shard ID and sequence, to their receivers.
+type MetadataDispatcher interface {
+	Dispatcher
+	DispatchWithMetadata(meta Metadata, event string, data json.RawMessage) error
+}
+
+// DispatchWithMetadata dispatches an event along with its metadata if d
+// supports it, and without it otherwise.
+func DispatchWithMetadata(d Dispatcher, meta Metadata, event string, data json.RawMessage) error {
+	if md, ok := d.(MetadataDispatcher); ok {
+		return md.DispatchWithMetadata(meta, event, data)
+	}
+	return d.Dispatch(event, data)
+}
+
 type DispatcherOption func(d Dispatcher)
 
 func WithLogger(l *zerolog.Logger) DispatcherOption {
@@ -0,0 +1,31 @@
+package dispatcher
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/rs/zerolog"
+)
+
+var _ MetadataDispatcher = (*recordingDispatcher)(nil)
+
+// recordingDispatcher records the events it receives and fails with err.
+type recordingDispatcher struct {
+	events []string
+	metas  []Metadata
+	err    error
+}
+
+func (d *recordingDispatcher) Dispatch(event string, data json.RawMessage) error {
+	d.events = append(d.events, event)
+	return d.err
+}
+
+func (d *recordingDispatcher) DispatchWithMetadata(meta Metadata, event string, data json.RawMessage) error {
+	d.metas = append(d.metas, meta)
+	return d.Dispatch(event, data)
+}
+
+func (d *recordingDispatcher) SetLogger(*zerolog.Logger) {}
+
+var errTarget = errors.New("target failed")
@@ -0,0 +1,155 @@
+package dispatcher
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"wumpgo.dev/wumpgo/gateway/internal/eventdata"
+	"wumpgo.dev/wumpgo/gateway/receiver"
+	"wumpgo.dev/wumpgo/objects"
+)
+
+var _ MetadataDispatcher = (*FilterDispatcher)(nil)
+
+// EventFilter reports whether an event matches.
+type EventFilter func(event string, data json.RawMessage) bool
+
+// EventNames matches events by their gateway name, e.g. "MESSAGE_CREATE".
+// Names are compared case-insensitively.
+func EventNames(names ...string) EventFilter {
+	set := make(map[string]struct{}, len(names))
+	for _, n := range names {
+		set[strings.ToUpper(n)] = struct{}{}
+	}
+
+	return func(event string, _ json.RawMessage) bool {
+		_, ok := set[strings.ToUpper(event)]
+		return ok
+	}
+}
+
+// GuildIDs matches events that belong to one of the given guilds.
+// Events that carry no guild ID never match.
+func GuildIDs(ids ...objects.Snowflake) EventFilter {
+	set := make(map[objects.Snowflake]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+
+	return func(event string, data json.RawMessage) bool {
+		id, ok := GuildIDFromEvent(event, data)
+		if !ok {
+			return false
+		}
+		_, ok = set[id]
+		return ok
+	}
+}
+
+// Not inverts a filter.
+func Not(f EventFilter) EventFilter {
+	return func(event string, data json.RawMessage) bool {
+		return !f(event, data)
+	}
+}
+
+// Any matches when at least one of the filters matches.
+func Any(filters ...EventFilter) EventFilter {
+	return func(event string, data json.RawMessage) bool {
+		for _, f := range filters {
+			if f(event, data) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// All matches when every filter matches.
+func All(filters ...EventFilter) EventFilter {
+	return func(event string, data json.RawMessage) bool {
+		for _, f := range filters {
+			if !f(event, data) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// GuildIDFromEvent extracts the guild ID from a raw gateway event without
+// decoding the full payload.
+func GuildIDFromEvent(event string, data json.RawMessage) (objects.Snowflake, bool) {
+	return eventdata.GuildID(event, data)
+}
+
+type filterRule struct {
+	filter EventFilter
+	target Dispatcher
+}
+
+// FilterDispatcher drops or routes events before they reach a dispatcher.
+//
+// Rules are evaluated in the order they were added and the first matching
+// rule decides where the event goes. Events matching no rule are passed to
+// the default dispatcher, or dropped if it is nil.
+type FilterDispatcher struct {
+	next   Dispatcher
+	rules  []filterRule
+	logger *zerolog.Logger
+}
+
+func NewFilterDispatcher(next Dispatcher, opts ...DispatcherOption) *FilterDispatcher {
+	logger := zerolog.Nop()
+
+	d := &FilterDispatcher{
+		next:   next,
+		logger: &logger,
+	}
+
+	for _, o := range opts {
+		o(d)
+	}
+
+	return d
+}
+
+// Drop discards every event matching the filter.
+func (d *FilterDispatcher) Drop(filter EventFilter) *FilterDispatcher {
+	d.rules = append(d.rules, filterRule{filter: filter})
+	return d
+}
+
+// Route sends every event matching the filter to target instead of the
+// default dispatcher.
+func (d *FilterDispatcher) Route(filter EventFilter, target Dispatcher) *FilterDispatcher {
+	d.rules = append(d.rules, filterRule{filter: filter, target: target})
+	return d
+}
+
+func (d *FilterDispatcher) Dispatch(event string, data json.RawMessage) error {
+	return d.DispatchWithMetadata(receiver.UnknownMetadata(), event, data)
+}
+
+func (d *FilterDispatcher) DispatchWithMetadata(meta Metadata, event string, data json.RawMessage) error {
+	target := d.next
+
+	for _, r := range d.rules {
+		if r.filter(event, data) {
+			target = r.target
+			break
+		}
+	}
+
+	if target == nil {
+		d.logger.Trace().Str("event", event).Msg("dropping filtered event")
+		return nil
+	}
+
+	return DispatchWithMetadata(target, meta, event, data)
+}
+
+func (d *FilterDispatcher) SetLogger(logger *zerolog.Logger) {
+	d.logger = logger
+}
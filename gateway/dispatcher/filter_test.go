@@ -0,0 +1,63 @@
+package dispatcher
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterDispatcher(t *testing.T) {
+	tests := []struct {
+		name  string
+		event string
+		data  string
+		want  string
+	}{
+		{name: "dropped", event: "TYPING_START", data: `{"guild_id":"1"}`, want: ""},
+		{name: "routed", event: "MESSAGE_CREATE", data: `{"guild_id":"2"}`, want: "guild"},
+		{name: "default", event: "MESSAGE_CREATE", data: `{"guild_id":"3"}`, want: "next"},
+		{name: "no guild", event: "MESSAGE_CREATE", data: `{}`, want: "next"},
+		{name: "first rule wins", event: "typing_start", data: `{"guild_id":"2"}`, want: ""},
+		{name: "guild event", event: "GUILD_CREATE", data: `{"id":"2"}`, want: "guild"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &recordingDispatcher{}
+			guild := &recordingDispatcher{}
+
+			d := NewFilterDispatcher(next).
+				Drop(EventNames("TYPING_START")).
+				Route(GuildIDs(2), guild)
+
+			require.NoError(t, d.Dispatch(tt.event, json.RawMessage(tt.data)))
+
+			got := ""
+			switch {
+			case len(next.events) == 1:
+				got = "next"
+			case len(guild.events) == 1:
+				got = "guild"
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFilterDispatcher_Combinators(t *testing.T) {
+	data := json.RawMessage(`{"guild_id":"5"}`)
+
+	require.True(t, Any(EventNames("a"), GuildIDs(5))("b", data))
+	require.False(t, All(EventNames("a"), GuildIDs(5))("b", data))
+	require.True(t, Not(GuildIDs(6))("b", data))
+}
+
+func TestFilterDispatcher_NilDefault(t *testing.T) {
+	target := &recordingDispatcher{err: errTarget}
+
+	d := NewFilterDispatcher(nil).Route(EventNames("MESSAGE_CREATE"), target)
+
+	require.NoError(t, d.Dispatch("MESSAGE_DELETE", []byte(`{}`)))
+	require.ErrorIs(t, d.Dispatch("MESSAGE_CREATE", []byte(`{}`)), errTarget)
+}
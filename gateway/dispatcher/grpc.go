@@ -0,0 +1,265 @@
+package dispatcher
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"wumpgo.dev/wumpgo/gateway/internal/eventstream"
+	"wumpgo.dev/wumpgo/gateway/receiver"
+)
+
+var (
+	_ MetadataDispatcher = (*GRPCDispatcher)(nil)
+	_ eventstream.Server = (*GRPCDispatcher)(nil)
+)
+
+const (
+	defaultGRPCBufferSize = 4096
+	defaultGRPCWindow     = 256
+)
+
+type GRPCDispatcherConf struct {
+	// Address to listen on, e.g. ":7000". Ignored if Listener is set.
+	Address string
+	// Listener overrides Address with an existing listener.
+	Listener net.Listener
+	// ServerOptions are passed to the embedded grpc.Server.
+	ServerOptions []grpc.ServerOption
+	// BufferSize is the number of recent events kept so that a receiver
+	// can resume after reconnecting. Defaults to 4096.
+	BufferSize int
+	// Window is the maximum number of unacknowledged events sent to a
+	// single receiver before the stream pauses. Defaults to 256.
+	Window int
+}
+
+// GRPCDispatcher streams events to GRPCReceivers from a gRPC server
+// embedded in the gateway process.
+type GRPCDispatcher struct {
+	server      *grpc.Server
+	listener    net.Listener
+	epoch       string
+	window      int
+	subscribers *atomic.Int64
+
+	mu     sync.Mutex
+	seq    uint64
+	buffer []eventstream.Event
+	notify chan struct{}
+
+	logger *zerolog.Logger
+}
+
+func NewGRPCDispatcher(conf *GRPCDispatcherConf, opts ...DispatcherOption) (*GRPCDispatcher, error) {
+	lis := conf.Listener
+	if lis == nil {
+		var err error
+		lis, err = net.Listen("tcp", conf.Address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bufferSize := conf.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultGRPCBufferSize
+	}
+
+	window := conf.Window
+	if window <= 0 {
+		window = defaultGRPCWindow
+	}
+
+	logger := zerolog.Nop()
+
+	d := &GRPCDispatcher{
+		listener:    lis,
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		window:      window,
+		subscribers: atomic.NewInt64(0),
+		buffer:      make([]eventstream.Event, bufferSize),
+		notify:      make(chan struct{}),
+		logger:      &logger,
+	}
+
+	for _, o := range opts {
+		o(d)
+	}
+
+	serverOpts := append([]grpc.ServerOption{grpc.ForceServerCodec(eventstream.Codec{})}, conf.ServerOptions...)
+	d.server = grpc.NewServer(serverOpts...)
+	d.server.RegisterService(&eventstream.ServiceDesc, d)
+
+	go func() {
+		if err := d.server.Serve(lis); err != nil {
+			d.logger.Error().Err(err).Msg("gRPC event stream server stopped")
+		}
+	}()
+
+	return d, nil
+}
+
+// Addr returns the address the embedded gRPC server is listening on.
+func (d *GRPCDispatcher) Addr() net.Addr {
+	return d.listener.Addr()
+}
+
+// Close stops the embedded gRPC server and disconnects all receivers.
+func (d *GRPCDispatcher) Close() {
+	d.server.Stop()
+}
+
+func (d *GRPCDispatcher) Dispatch(event string, data json.RawMessage) error {
+	return d.DispatchWithMetadata(receiver.UnknownMetadata(), event, data)
+}
+
+func (d *GRPCDispatcher) DispatchWithMetadata(meta Metadata, event string, data json.RawMessage) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.seq++
+	d.buffer[d.seq%uint64(len(d.buffer))] = eventstream.Event{
+		Sequence:        d.seq,
+		Name:            event,
+		Data:            data,
+		Shard:           meta.Shard,
+		GatewaySequence: meta.Sequence,
+		Session:         meta.Session,
+	}
+
+	close(d.notify)
+	d.notify = make(chan struct{})
+
+	d.logger.Trace().Str("event", event).Uint64("sequence", d.seq).Msg("Dispatching event to gRPC stream")
+
+	return nil
+}
+
+func (d *GRPCDispatcher) SetLogger(logger *zerolog.Logger) {
+	d.logger = logger
+}
+
+// oldest returns the oldest sequence still held in the buffer.
+// d.mu must be held.
+func (d *GRPCDispatcher) oldest() uint64 {
+	size := uint64(len(d.buffer))
+	if d.seq < size {
+		return 1
+	}
+	return d.seq - size + 1
+}
+
+// Subscribe implements eventstream.Server.
+func (d *GRPCDispatcher) Subscribe(stream grpc.ServerStream) error {
+	var req eventstream.SubscribeRequest
+	if err := stream.RecvMsg(&req); err != nil {
+		return err
+	}
+
+	if err := stream.SendHeader(metadata.Pairs(eventstream.EpochHeader, d.epoch)); err != nil {
+		return err
+	}
+
+	filter := make(map[string]struct{}, len(req.Events))
+	for _, e := range req.Events {
+		filter[strings.ToUpper(e)] = struct{}{}
+	}
+
+	d.mu.Lock()
+	next := d.seq + 1
+	if req.Epoch == d.epoch && req.ResumeFrom > 0 && req.ResumeFrom < d.seq {
+		next = req.ResumeFrom + 1
+	}
+	d.mu.Unlock()
+
+	log := d.logger.With().Uint64("from", next).Int("events", len(filter)).Logger()
+	log.Info().Msg("receiver subscribed to gRPC event stream")
+	d.subscribers.Inc()
+	defer d.subscribers.Dec()
+
+	acks := make(chan uint64, 1)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			var ack eventstream.Ack
+			if err := stream.RecvMsg(&ack); err != nil {
+				recvErr <- err
+				return
+			}
+			// Acks are cumulative, so only the most recent one matters.
+			select {
+			case <-acks:
+			default:
+			}
+			acks <- ack.Sequence
+		}
+	}()
+
+	ctx := stream.Context()
+	acked := next - 1
+	inflight := make([]uint64, 0, d.window)
+
+	for {
+		for len(inflight) > 0 && inflight[0] <= acked {
+			inflight = inflight[1:]
+		}
+
+		d.mu.Lock()
+		notify := d.notify
+		head := d.seq
+		d.mu.Unlock()
+
+		if next > head || len(inflight) >= d.window {
+			select {
+			case <-notify:
+			case a := <-acks:
+				if a > acked {
+					acked = a
+				}
+			case err := <-recvErr:
+				if err == io.EOF {
+					return nil
+				}
+				return err
+			case <-ctx.Done():
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			continue
+		}
+
+		d.mu.Lock()
+		if oldest := d.oldest(); next < oldest {
+			log.Warn().Uint64("missed", oldest-next).Msg("receiver fell behind the event buffer")
+			next = oldest
+		}
+		ev := d.buffer[next%uint64(len(d.buffer))]
+		d.mu.Unlock()
+
+		next++
+
+		if len(filter) > 0 {
+			if _, ok := filter[strings.ToUpper(ev.Name)]; !ok {
+				continue
+			}
+		}
+
+		if err := stream.SendMsg(&ev); err != nil {
+			if status.Code(err) == codes.Canceled {
+				return nil
+			}
+			return err
+		}
+		inflight = append(inflight, ev.Sequence)
+	}
+}
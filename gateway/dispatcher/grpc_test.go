@@ -0,0 +1,197 @@
+package dispatcher
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"wumpgo.dev/wumpgo/gateway/internal/eventstream"
+	"wumpgo.dev/wumpgo/gateway/receiver"
+	"wumpgo.dev/wumpgo/objects"
+	"wumpgo.dev/wumpgo/rest"
+)
+
+func newTestGRPCPair(t *testing.T, conf *GRPCDispatcherConf, opts ...receiver.ReceiverOption) (*GRPCDispatcher, *receiver.GRPCReceiver) {
+	lis := bufconn.Listen(1 << 20)
+	conf.Listener = lis
+
+	d, err := NewGRPCDispatcher(conf)
+	require.NoError(t, err)
+	t.Cleanup(d.Close)
+
+	r, err := receiver.NewGRPCReceiver("bufnet", []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}, opts...)
+	require.NoError(t, err)
+
+	return d, r
+}
+
+func waitForSubscribers(t *testing.T, d *GRPCDispatcher, n int64) {
+	require.Eventually(t, func() bool {
+		return d.subscribers.Load() == n
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestGRPCDispatcher_Stream(t *testing.T) {
+	d, r := newTestGRPCPair(t, &GRPCDispatcherConf{})
+
+	received := make(chan string, 10)
+	_, err := r.On(func(_ context.Context, _ rest.RESTClient, m *objects.MessageCreate) {
+		received <- m.Content
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = r.Run(ctx) }()
+
+	waitForSubscribers(t, d, 1)
+
+	require.NoError(t, d.Dispatch("TYPING_START", []byte(`{"channel_id":"1"}`)))
+	require.NoError(t, d.Dispatch("MESSAGE_CREATE", []byte(`{"content":"first"}`)))
+	require.NoError(t, d.Dispatch("MESSAGE_CREATE", []byte(`{"content":"second"}`)))
+
+	for _, want := range []string{"first", "second"} {
+		select {
+		case got := <-received:
+			require.Equal(t, want, got)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %q", want)
+		}
+	}
+}
+
+func TestGRPCDispatcher_Events(t *testing.T) {
+	d, r := newTestGRPCPair(t, &GRPCDispatcherConf{}, receiver.WithGRPCEvents("MESSAGE_CREATE"))
+
+	typing := make(chan struct{}, 10)
+	_, err := r.On(func(_ context.Context, _ rest.RESTClient, _ *objects.TypingStart) {
+		typing <- struct{}{}
+	})
+	require.NoError(t, err)
+
+	received := make(chan string, 10)
+	_, err = r.On(func(_ context.Context, _ rest.RESTClient, m *objects.MessageCreate) {
+		received <- m.Content
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = r.Run(ctx) }()
+
+	waitForSubscribers(t, d, 1)
+
+	require.NoError(t, d.Dispatch("TYPING_START", []byte(`{"channel_id":"1"}`)))
+	require.NoError(t, d.Dispatch("MESSAGE_CREATE", []byte(`{"content":"first"}`)))
+
+	select {
+	case got := <-received:
+		require.Equal(t, "first", got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the message")
+	}
+
+	// Events are sent in order, so the typing event would have been handled
+	// by now.
+	require.Empty(t, typing)
+}
+
+func TestGRPCDispatcher_Resume(t *testing.T) {
+	lis := bufconn.Listen(1 << 20)
+	d, err := NewGRPCDispatcher(&GRPCDispatcherConf{Listener: lis, BufferSize: 4})
+	require.NoError(t, err)
+	t.Cleanup(d.Close)
+
+	for i := 0; i < 6; i++ {
+		require.NoError(t, d.Dispatch("MESSAGE_CREATE", []byte(`{}`)))
+	}
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(eventstream.Codec{})),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	tests := []struct {
+		name string
+		req  *eventstream.SubscribeRequest
+		want uint64
+	}{
+		{"resume in buffer", &eventstream.SubscribeRequest{Epoch: d.epoch, ResumeFrom: 4}, 5},
+		{"resume behind buffer", &eventstream.SubscribeRequest{Epoch: d.epoch, ResumeFrom: 1}, 3},
+		{"resume from other epoch", &eventstream.SubscribeRequest{Epoch: "old", ResumeFrom: 4}, 7},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			stream, err := conn.NewStream(ctx, eventstream.StreamDesc, eventstream.SubscribePath)
+			require.NoError(t, err)
+			require.NoError(t, stream.SendMsg(tc.req))
+
+			if tc.want > 6 {
+				waitForSubscribers(t, d, 1)
+				require.NoError(t, d.Dispatch("MESSAGE_CREATE", []byte(`{}`)))
+			}
+
+			var ev eventstream.Event
+			require.NoError(t, stream.RecvMsg(&ev))
+			require.Equal(t, tc.want, ev.Sequence)
+
+			cancel()
+			waitForSubscribers(t, d, 0)
+		})
+	}
+}
+
+func TestGRPCDispatcher_LateRegistration(t *testing.T) {
+	d, r := newTestGRPCPair(t, &GRPCDispatcherConf{})
+
+	_, err := r.On(func(_ context.Context, _ rest.RESTClient, _ *objects.TypingStart) {})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = r.Run(ctx) }()
+
+	waitForSubscribers(t, d, 1)
+
+	got := make(chan *objects.MessageCreate, 1)
+	go func() {
+		m, err := receiver.WaitFor(ctx, r, func(m *objects.MessageCreate) bool {
+			return m.Content == "late"
+		})
+		if err == nil {
+			got <- m
+		}
+	}()
+
+	// WaitFor registers its handler asynchronously, keep dispatching until
+	// it picks an event up.
+	require.Eventually(t, func() bool {
+		assert.NoError(t, d.Dispatch("MESSAGE_CREATE", []byte(`{"content":"late"}`)))
+		select {
+		case <-got:
+			return true
+		default:
+			return false
+		}
+	}, 5*time.Second, 50*time.Millisecond)
+}
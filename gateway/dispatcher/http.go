@@ -0,0 +1,189 @@
+package dispatcher
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/rs/zerolog"
+	"wumpgo.dev/wumpgo/gateway/internal/webhook"
+	"wumpgo.dev/wumpgo/gateway/receiver"
+)
+
+var _ MetadataDispatcher = (*HTTPDispatcher)(nil)
+
+type HTTPDispatcherConf struct {
+	// Endpoints every event is POSTed to.
+	Endpoints []string
+	// Secret used to sign deliveries. Receivers must be configured with
+	// the same secret.
+	Secret []byte
+	// Client used to deliver events. Defaults to a client with a 10 second
+	// timeout.
+	Client *http.Client
+	// MaxElapsedTime bounds the time spent retrying a single delivery.
+	// Defaults to one minute.
+	MaxElapsedTime time.Duration
+}
+
+// HTTPDispatcher POSTs every event to one or more HTTP endpoints.
+//
+// Each delivery carries the event name, a delivery ID, a timestamp and an
+// HMAC signature in its headers. Failed deliveries are retried with
+// exponential backoff unless the endpoint answered with a 4xx status other
+// than 408 and 429.
+type HTTPDispatcher struct {
+	endpoints      []string
+	secret         []byte
+	client         *http.Client
+	maxElapsedTime time.Duration
+	logger         *zerolog.Logger
+}
+
+func NewHTTPDispatcher(conf *HTTPDispatcherConf, opts ...DispatcherOption) *HTTPDispatcher {
+	client := conf.Client
+	if client == nil {
+		client = &http.Client{Timeout: time.Second * 10}
+	}
+
+	maxElapsed := conf.MaxElapsedTime
+	if maxElapsed <= 0 {
+		maxElapsed = time.Minute
+	}
+
+	logger := zerolog.Nop()
+
+	d := &HTTPDispatcher{
+		endpoints:      conf.Endpoints,
+		secret:         conf.Secret,
+		client:         client,
+		maxElapsedTime: maxElapsed,
+		logger:         &logger,
+	}
+
+	for _, o := range opts {
+		o(d)
+	}
+
+	return d
+}
+
+func (d *HTTPDispatcher) Dispatch(event string, data json.RawMessage) error {
+	return d.DispatchWithMetadata(receiver.UnknownMetadata(), event, data)
+}
+
+func (d *HTTPDispatcher) DispatchWithMetadata(meta Metadata, event string, data json.RawMessage) error {
+	id, err := newDeliveryID()
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, len(d.endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range d.endpoints {
+		wg.Add(1)
+		go func(i int, endpoint string) {
+			defer wg.Done()
+			errs[i] = d.deliver(endpoint, id, meta, event, data)
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	var failed []string
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to deliver %s: %s", event, strings.Join(failed, "; "))
+	}
+
+	return nil
+}
+
+func (d *HTTPDispatcher) deliver(endpoint, id string, meta Metadata, event string, data json.RawMessage) error {
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = d.maxElapsedTime
+
+	attempt := 0
+	op := func() error {
+		attempt++
+		err := d.post(endpoint, id, meta, event, data)
+		if err != nil {
+			d.logger.Debug().Err(err).Str("endpoint", endpoint).Str("event", event).Int("attempt", attempt).Msg("delivery attempt failed")
+		}
+		return err
+	}
+
+	if err := backoff.Retry(op, b); err != nil {
+		return fmt.Errorf("%s: %w", endpoint, err)
+	}
+
+	d.logger.Trace().Str("endpoint", endpoint).Str("event", event).Int("attempts", attempt).Msg("Delivered event over HTTP")
+	return nil
+}
+
+func (d *HTTPDispatcher) post(endpoint, id string, meta Metadata, event string, data json.RawMessage) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return backoff.Permanent(err)
+	}
+
+	ts := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.EventHeader, event)
+	req.Header.Set(webhook.DeliveryHeader, id)
+	req.Header.Set(webhook.TimestampHeader, strconv.FormatInt(ts, 10))
+	if meta.Shard >= 0 {
+		req.Header.Set(webhook.ShardHeader, strconv.Itoa(meta.Shard))
+	}
+	if meta.Sequence > 0 {
+		req.Header.Set(webhook.SequenceHeader, strconv.FormatUint(meta.Sequence, 10))
+	}
+	if meta.Session != "" {
+		req.Header.Set(webhook.SessionHeader, meta.Session)
+	}
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(d.secret, req.Header, data))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	err = fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return backoff.Permanent(err)
+	}
+
+	return err
+}
+
+func (d *HTTPDispatcher) SetLogger(logger *zerolog.Logger) {
+	d.logger = logger
+}
+
+func newDeliveryID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,82 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"wumpgo.dev/wumpgo/gateway/receiver"
+	"wumpgo.dev/wumpgo/objects"
+	"wumpgo.dev/wumpgo/rest"
+)
+
+func TestHTTPDispatcher_Deliver(t *testing.T) {
+	r := receiver.NewHTTPReceiver(&receiver.HTTPReceiverConf{Secret: []byte("secret")})
+
+	received := make(chan string, 1)
+	_, err := r.On(func(_ context.Context, _ rest.RESTClient, m *objects.MessageCreate) {
+		received <- m.Content
+	})
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	d := NewHTTPDispatcher(&HTTPDispatcherConf{
+		Endpoints: []string{srv.URL},
+		Secret:    []byte("secret"),
+	})
+
+	require.NoError(t, d.Dispatch("MESSAGE_CREATE", []byte(`{"content":"hello"}`)))
+
+	select {
+	case got := <-received:
+		require.Equal(t, "hello", got)
+	case <-time.After(time.Second):
+		t.Fatal("handler was not called")
+	}
+}
+
+func TestHTTPDispatcher_BadSecret(t *testing.T) {
+	r := receiver.NewHTTPReceiver(&receiver.HTTPReceiverConf{Secret: []byte("secret")})
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	d := NewHTTPDispatcher(&HTTPDispatcherConf{
+		Endpoints:      []string{srv.URL},
+		Secret:         []byte("wrong"),
+		MaxElapsedTime: time.Second,
+	})
+
+	require.ErrorContains(t, d.Dispatch("MESSAGE_CREATE", []byte(`{}`)), "401")
+}
+
+func TestHTTPDispatcher_RedeliversFailedHandler(t *testing.T) {
+	r := receiver.NewHTTPReceiver(&receiver.HTTPReceiverConf{Secret: []byte("secret")})
+
+	attempts := 0
+	_, err := r.On(func(_ context.Context, _ rest.RESTClient, _ *objects.MessageCreate) error {
+		attempts++
+		if attempts == 1 {
+			return errors.New("database is down")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	d := NewHTTPDispatcher(&HTTPDispatcherConf{
+		Endpoints:      []string{srv.URL},
+		Secret:         []byte("secret"),
+		MaxElapsedTime: 5 * time.Second,
+	})
+
+	require.NoError(t, d.Dispatch("MESSAGE_CREATE", []byte(`{}`)))
+	require.Equal(t, 2, attempts)
+}
@@ -7,7 +7,7 @@ import (
 	"wumpgo.dev/wumpgo/gateway/receiver"
 )
 
-var _ Dispatcher = (*LocalDispatcher)(nil)
+var _ MetadataDispatcher = (*LocalDispatcher)(nil)
 
 type LocalDispatcher struct {
 	receiver receiver.Receiver
@@ -33,6 +33,13 @@ func (l *LocalDispatcher) Dispatch(event string, data json.RawMessage) error {
 	return l.receiver.Route(event, data)
 }
 
+func (l *LocalDispatcher) DispatchWithMetadata(meta Metadata, event string, data json.RawMessage) error {
+	if r, ok := l.receiver.(receiver.MetadataRouter); ok {
+		return r.RouteWithMetadata(meta, event, data)
+	}
+	return l.receiver.Route(event, data)
+}
+
 func (l *LocalDispatcher) SetLogger(logger *zerolog.Logger) {
 	l.logger = logger
 }
@@ -0,0 +1,137 @@
+package dispatcher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"wumpgo.dev/wumpgo/gateway/receiver"
+)
+
+var _ MetadataDispatcher = (*MultiDispatcher)(nil)
+
+// FailurePolicy decides what a MultiDispatcher does when one of its
+// targets fails to dispatch an event.
+type FailurePolicy int
+
+const (
+	// FailurePolicyIgnore logs the error and carries on as if the
+	// target succeeded.
+	FailurePolicyIgnore FailurePolicy = iota
+	// FailurePolicyReport keeps dispatching to the remaining targets
+	// and returns the error once every target has been tried.
+	FailurePolicyReport
+	// FailurePolicyAbort stops dispatching to the remaining targets and
+	// returns the error immediately.
+	FailurePolicyAbort
+)
+
+// Target is a single backend of a MultiDispatcher.
+type Target struct {
+	Dispatcher Dispatcher
+	Policy     FailurePolicy
+}
+
+// MultiDispatchError is returned by MultiDispatcher.Dispatch when one or more
+// targets with a non ignoring FailurePolicy fail.
+type MultiDispatchError struct {
+	Event  string
+	Errors []error
+}
+
+func (e *MultiDispatchError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("failed to dispatch %s: %s", e.Event, strings.Join(msgs, "; "))
+}
+
+// Is reports whether any of the target errors matches target.
+func (e *MultiDispatchError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first target error that matches target.
+func (e *MultiDispatchError) As(target interface{}) bool {
+	for _, err := range e.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *MultiDispatchError) Unwrap() []error {
+	return e.Errors
+}
+
+// MultiDispatcher tees every event to several dispatchers, in the order
+// the targets were given.
+type MultiDispatcher struct {
+	targets []Target
+	logger  *zerolog.Logger
+}
+
+func NewMultiDispatcher(targets []Target, opts ...DispatcherOption) *MultiDispatcher {
+	logger := zerolog.Nop()
+
+	d := &MultiDispatcher{
+		targets: targets,
+		logger:  &logger,
+	}
+
+	for _, o := range opts {
+		o(d)
+	}
+
+	return d
+}
+
+// AddTarget appends a dispatcher to the list of targets.
+// It is not safe to call once the dispatcher is in use by a shard.
+func (d *MultiDispatcher) AddTarget(dispatcher Dispatcher, policy FailurePolicy) {
+	d.targets = append(d.targets, Target{Dispatcher: dispatcher, Policy: policy})
+}
+
+func (d *MultiDispatcher) Dispatch(event string, data json.RawMessage) error {
+	return d.DispatchWithMetadata(receiver.UnknownMetadata(), event, data)
+}
+
+func (d *MultiDispatcher) DispatchWithMetadata(meta Metadata, event string, data json.RawMessage) error {
+	var errs []error
+
+	for i, t := range d.targets {
+		err := DispatchWithMetadata(t.Dispatcher, meta, event, data)
+		if err == nil {
+			continue
+		}
+
+		d.logger.Warn().Err(err).Str("event", event).Int("target", i).Msg("target failed to dispatch event")
+
+		switch t.Policy {
+		case FailurePolicyReport:
+			errs = append(errs, err)
+		case FailurePolicyAbort:
+			errs = append(errs, err)
+			return &MultiDispatchError{Event: event, Errors: errs}
+		}
+	}
+
+	if len(errs) > 0 {
+		return &MultiDispatchError{Event: event, Errors: errs}
+	}
+
+	return nil
+}
+
+func (d *MultiDispatcher) SetLogger(logger *zerolog.Logger) {
+	d.logger = logger
+}
@@ -0,0 +1,66 @@
+package dispatcher
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"wumpgo.dev/wumpgo/gateway/receiver"
+)
+
+func TestMultiDispatcher_Policies(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  FailurePolicy
+		reached bool
+		errs    int
+	}{
+		{name: "ignore", policy: FailurePolicyIgnore, reached: true, errs: 0},
+		{name: "report", policy: FailurePolicyReport, reached: true, errs: 1},
+		{name: "abort", policy: FailurePolicyAbort, reached: false, errs: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failing := &recordingDispatcher{err: errTarget}
+			next := &recordingDispatcher{}
+
+			d := NewMultiDispatcher([]Target{{Dispatcher: failing, Policy: tt.policy}})
+			d.AddTarget(next, FailurePolicyReport)
+
+			meta := receiver.EventMetadata{Shard: 2, Sequence: 7}
+			err := d.DispatchWithMetadata(meta, "MESSAGE_CREATE", []byte(`{}`))
+
+			require.Equal(t, []string{"MESSAGE_CREATE"}, failing.events)
+			require.Equal(t, []Metadata{meta}, failing.metas)
+			require.Equal(t, tt.reached, len(next.events) == 1)
+
+			if tt.errs == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var multiErr *MultiDispatchError
+			require.ErrorAs(t, err, &multiErr)
+			require.Len(t, multiErr.Errors, tt.errs)
+			require.Equal(t, "MESSAGE_CREATE", multiErr.Event)
+		})
+	}
+}
+
+func TestMultiDispatchError_IsAs(t *testing.T) {
+	opErr := &net.OpError{Op: "dial", Err: errors.New("refused")}
+	err := fmt.Errorf("dispatch: %w", &MultiDispatchError{
+		Event:  "MESSAGE_CREATE",
+		Errors: []error{fmt.Errorf("redis: %w", errTarget), opErr},
+	})
+
+	require.True(t, errors.Is(err, errTarget))
+	require.False(t, errors.Is(err, errors.New("target failed")))
+
+	var got *net.OpError
+	require.True(t, errors.As(err, &got))
+	require.Same(t, opErr, got)
+}
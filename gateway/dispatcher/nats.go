@@ -3,13 +3,15 @@ package dispatcher
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/nats-io/nats.go"
 	"github.com/rs/zerolog"
+	"wumpgo.dev/wumpgo/gateway/receiver"
 )
 
-var _ Dispatcher = (*NATSDispatcher)(nil)
+var _ MetadataDispatcher = (*NATSDispatcher)(nil)
 
 type NATSDispatcher struct {
 	conn   *nats.Conn
@@ -42,6 +44,25 @@ func (d *NATSDispatcher) Dispatch(event string, data json.RawMessage) error {
 	return d.conn.Publish(eventName, data)
 }
 
+// DispatchWithMetadata sends the metadata as message headers if the server
+// supports them.
+func (d *NATSDispatcher) DispatchWithMetadata(meta Metadata, event string, data json.RawMessage) error {
+	if !d.conn.HeadersSupported() {
+		return d.Dispatch(event, data)
+	}
+
+	msg := nats.NewMsg(fmt.Sprintf("discord.%s", strings.ToLower(event)))
+	msg.Data = data
+	msg.Header.Set(receiver.NATSShardHeader, strconv.Itoa(meta.Shard))
+	msg.Header.Set(receiver.NATSSequenceHeader, strconv.FormatUint(meta.Sequence, 10))
+	if meta.Session != "" {
+		msg.Header.Set(receiver.NATSSessionHeader, meta.Session)
+	}
+
+	d.logger.Debug().Msgf("Dispatching event %s to NATS", msg.Subject)
+	return d.conn.PublishMsg(msg)
+}
+
 func (d *NATSDispatcher) SetLogger(logger *zerolog.Logger) {
 	d.logger = logger
 }
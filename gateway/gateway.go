@@ -3,6 +3,7 @@ package gateway
 import (
 	"github.com/nats-io/nats.go"
 	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc"
 	"wumpgo.dev/wumpgo/gateway/dispatcher"
 	"wumpgo.dev/wumpgo/gateway/manager"
 	"wumpgo.dev/wumpgo/gateway/receiver"
@@ -33,6 +34,22 @@ func NewRedisDispatcher(connectOpts *redis.Options, opts ...dispatcher.Dispatche
 	return dispatcher.NewRedisDispatcher(connectOpts)
 }
 
+func NewGRPCDispatcher(conf *dispatcher.GRPCDispatcherConf, opts ...dispatcher.DispatcherOption) (dispatcher.Dispatcher, error) {
+	return dispatcher.NewGRPCDispatcher(conf, opts...)
+}
+
+func NewHTTPDispatcher(conf *dispatcher.HTTPDispatcherConf, opts ...dispatcher.DispatcherOption) dispatcher.Dispatcher {
+	return dispatcher.NewHTTPDispatcher(conf, opts...)
+}
+
+func NewMultiDispatcher(targets []dispatcher.Target, opts ...dispatcher.DispatcherOption) *dispatcher.MultiDispatcher {
+	return dispatcher.NewMultiDispatcher(targets, opts...)
+}
+
+func NewFilterDispatcher(next dispatcher.Dispatcher, opts ...dispatcher.DispatcherOption) *dispatcher.FilterDispatcher {
+	return dispatcher.NewFilterDispatcher(next, opts...)
+}
+
 func NewLocalReceiver(opts ...receiver.ReceiverOption) receiver.Receiver {
 	return receiver.NewLocalReceiver(opts...)
 }
@@ -41,6 +58,14 @@ func NewNATSReceiver(url string, natsOptions []nats.Option, opts ...receiver.Rec
 	return receiver.NewNATSReceiver(url, natsOptions, opts...)
 }
 
+func NewGRPCReceiver(target string, dialOpts []grpc.DialOption, opts ...receiver.ReceiverOption) (receiver.Receiver, error) {
+	return receiver.NewGRPCReceiver(target, dialOpts, opts...)
+}
+
+func NewHTTPReceiver(conf *receiver.HTTPReceiverConf, opts ...receiver.ReceiverOption) *receiver.HTTPReceiver {
+	return receiver.NewHTTPReceiver(conf, opts...)
+}
+
 func NewRedisReceiver(connectOpts *redis.Options, opts ...receiver.ReceiverOption) (receiver.Receiver, error) {
 	return receiver.NewRedisReceiver(connectOpts, opts...)
 }
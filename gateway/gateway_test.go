@@ -0,0 +1,28 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"wumpgo.dev/wumpgo/objects"
+	"wumpgo.dev/wumpgo/rest"
+)
+
+func TestNewLocalReceiver_TypedHandlers(t *testing.T) {
+	r := NewLocalReceiver()
+
+	var got string
+	reg := r.OnMessageCreate(func(_ context.Context, _ rest.RESTClient, m *objects.MessageCreate) {
+		got = m.Content
+	})
+	require.Equal(t, "message_create", reg.Event())
+
+	d := NewLocalDispatcher(r)
+	require.NoError(t, d.Dispatch("MESSAGE_CREATE", []byte(`{"content":"hi"}`)))
+	require.Equal(t, "hi", got)
+
+	reg.Remove()
+	require.NoError(t, d.Dispatch("MESSAGE_CREATE", []byte(`{"content":"bye"}`)))
+	require.Equal(t, "hi", got)
+}
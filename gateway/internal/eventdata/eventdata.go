@@ -0,0 +1,34 @@
+// Package eventdata extracts common fields from raw gateway events without
+// decoding the full payload.
+package eventdata
+
+import (
+	"encoding/json"
+	"strings"
+
+	"wumpgo.dev/wumpgo/objects"
+)
+
+type guildIDPayload struct {
+	GuildID *objects.Snowflake `json:"guild_id"`
+	ID      objects.Snowflake  `json:"id"`
+}
+
+// GuildID returns the guild an event belongs to.
+func GuildID(event string, data json.RawMessage) (objects.Snowflake, bool) {
+	var p guildIDPayload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return 0, false
+	}
+
+	switch strings.ToUpper(event) {
+	case "GUILD_CREATE", "GUILD_UPDATE", "GUILD_DELETE":
+		return p.ID, p.ID != 0
+	}
+
+	if p.GuildID == nil || *p.GuildID == 0 {
+		return 0, false
+	}
+
+	return *p.GuildID, true
+}
@@ -0,0 +1,93 @@
+// Package eventstream holds the wire definitions shared by the gRPC
+// dispatcher and receiver.
+//
+// The service is described by hand and messages are encoded as JSON, so no
+// protobuf tooling is needed to build or extend it.
+package eventstream
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	ServiceName   = "wumpgo.gateway.EventStream"
+	SubscribeName = "Subscribe"
+	SubscribePath = "/" + ServiceName + "/" + SubscribeName
+
+	// EpochHeader is sent in the stream header metadata. Sequence numbers
+	// are only comparable between streams with the same epoch.
+	EpochHeader = "x-wumpgo-epoch"
+)
+
+// SubscribeRequest is sent by a receiver when it opens a stream.
+type SubscribeRequest struct {
+	// Events limits the stream to the given gateway event names.
+	// An empty list subscribes to every event.
+	Events []string `json:"events,omitempty"`
+	// Epoch is the epoch of the stream ResumeFrom belongs to.
+	Epoch string `json:"epoch,omitempty"`
+	// ResumeFrom is the last sequence acknowledged by the receiver.
+	// Zero starts with the next dispatched event.
+	ResumeFrom uint64 `json:"resume_from,omitempty"`
+}
+
+// Ack acknowledges every event up to and including Sequence.
+type Ack struct {
+	Sequence uint64 `json:"sequence"`
+}
+
+// Event is a single gateway event on the stream.
+type Event struct {
+	// Sequence is the position of the event on the stream.
+	Sequence uint64          `json:"sequence"`
+	Name     string          `json:"name"`
+	Data     json.RawMessage `json:"data"`
+	// Shard that received the event, -1 if unknown.
+	Shard int `json:"shard"`
+	// GatewaySequence is the sequence number sent by Discord, 0 if unknown.
+	GatewaySequence uint64 `json:"gateway_sequence,omitempty"`
+	// Session is the gateway session ID of the shard, empty if unknown.
+	Session string `json:"session,omitempty"`
+}
+
+// Server is implemented by the dispatcher side of the stream.
+type Server interface {
+	Subscribe(stream grpc.ServerStream) error
+}
+
+// ServiceDesc describes the EventStream service for grpc.Server.RegisterService.
+var ServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*Server)(nil),
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    SubscribeName,
+			ServerStreams: true,
+			ClientStreams: true,
+			Handler: func(srv interface{}, stream grpc.ServerStream) error {
+				return srv.(Server).Subscribe(stream)
+			},
+		},
+	},
+	Metadata: "eventstream.go",
+}
+
+// StreamDesc is the client side description of the Subscribe stream.
+var StreamDesc = &ServiceDesc.Streams[0]
+
+// Codec encodes stream messages as JSON.
+type Codec struct{}
+
+func (Codec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (Codec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (Codec) Name() string {
+	return "json"
+}
@@ -0,0 +1,93 @@
+// Package webhook implements the signing scheme shared by the HTTP
+// dispatcher and receiver.
+//
+// A delivery is signed with HMAC-SHA256 using the shared secret over
+//
+//	<timestamp>.<event>.<delivery>.<shard>.<sequence>.<session>.<body>
+//
+// where each field but the body is the value of its header, or empty if the
+// header isn't set, and timestamp is the Unix time in seconds. The hex
+// encoded digest is sent in SignatureHeader prefixed with "sha256=".
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	EventHeader     = "X-Wumpgo-Event"
+	DeliveryHeader  = "X-Wumpgo-Delivery"
+	TimestampHeader = "X-Wumpgo-Timestamp"
+	SignatureHeader = "X-Wumpgo-Signature"
+	ShardHeader     = "X-Wumpgo-Shard"
+	SequenceHeader  = "X-Wumpgo-Sequence"
+	SessionHeader   = "X-Wumpgo-Session"
+
+	signaturePrefix = "sha256="
+)
+
+// signedHeaders are the headers covered by the signature, in order.
+var signedHeaders = []string{
+	TimestampHeader,
+	EventHeader,
+	DeliveryHeader,
+	ShardHeader,
+	SequenceHeader,
+	SessionHeader,
+}
+
+var (
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrInvalidTimestamp = errors.New("invalid timestamp")
+	ErrExpiredTimestamp = errors.New("timestamp outside of tolerance")
+)
+
+// Sign returns the value of SignatureHeader for a delivery with the given
+// headers, which must already be set.
+func Sign(secret []byte, h http.Header, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	for _, name := range signedHeaders {
+		mac.Write([]byte(h.Get(name)))
+		mac.Write([]byte("."))
+	}
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature and timestamp of a delivery. A zero tolerance
+// disables the timestamp check.
+func Verify(secret []byte, h http.Header, body []byte, tolerance time.Duration) error {
+	ts, err := strconv.ParseInt(h.Get(TimestampHeader), 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+
+	if tolerance > 0 {
+		diff := time.Since(time.Unix(ts, 0))
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > tolerance {
+			return ErrExpiredTimestamp
+		}
+	}
+
+	signature := h.Get(SignatureHeader)
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return ErrInvalidSignature
+	}
+
+	expected := Sign(secret, h, body)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
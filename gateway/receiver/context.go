@@ -0,0 +1,146 @@
+package receiver
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// EventMetadata describes where an event came from. It is available to
+// handlers through the context they are called with.
+type EventMetadata struct {
+	// Name is the gateway event name, e.g. MESSAGE_CREATE.
+	Name string
+	// Shard is the ID of the shard that received the event, or -1 if the
+	// transport did not carry it.
+	Shard int
+	// Sequence is the gateway sequence number of the event, or 0 if the
+	// transport did not carry it.
+	Sequence uint64
+	// Session is the gateway session ID of the shard, or empty if the
+	// transport did not carry it. Sequence numbers are only unique within a
+	// session.
+	Session string
+	// ReceivedAt is when the receiver routed the event.
+	ReceivedAt time.Time
+}
+
+// UnknownMetadata returns metadata for an event whose origin is unknown.
+func UnknownMetadata() EventMetadata {
+	return EventMetadata{Shard: -1}
+}
+
+// MetadataRouter is implemented by receivers that can route an event along
+// with its metadata. Every receiver in this package implements it.
+type MetadataRouter interface {
+	RouteWithMetadata(meta EventMetadata, event string, data json.RawMessage) error
+}
+
+// ParseMetadata builds metadata from the string encoded shard and sequence
+// used by transports that carry metadata in headers.
+func ParseMetadata(shard, sequence string) EventMetadata {
+	meta := UnknownMetadata()
+	if s, err := strconv.Atoi(shard); err == nil {
+		meta.Shard = s
+	}
+	if s, err := strconv.ParseUint(sequence, 10, 64); err == nil {
+		meta.Sequence = s
+	}
+	return meta
+}
+
+type metadataKey struct{}
+
+// MetadataFromContext returns the metadata of the event being handled.
+func MetadataFromContext(ctx context.Context) (EventMetadata, bool) {
+	meta, ok := ctx.Value(metadataKey{}).(EventMetadata)
+	return meta, ok
+}
+
+// EventNameFromContext returns the gateway name of the event being handled.
+func EventNameFromContext(ctx context.Context) string {
+	meta, _ := MetadataFromContext(ctx)
+	return meta.Name
+}
+
+// ShardFromContext returns the shard that received the event being handled.
+func ShardFromContext(ctx context.Context) (int, bool) {
+	meta, ok := MetadataFromContext(ctx)
+	if !ok || meta.Shard < 0 {
+		return 0, false
+	}
+	return meta.Shard, true
+}
+
+// SequenceFromContext returns the gateway sequence of the event being handled.
+func SequenceFromContext(ctx context.Context) (uint64, bool) {
+	meta, ok := MetadataFromContext(ctx)
+	if !ok || meta.Sequence == 0 {
+		return 0, false
+	}
+	return meta.Sequence, true
+}
+
+// LoggerFromContext returns the logger scoped to the event being handled.
+// It is the receiver's logger with the event metadata attached.
+func LoggerFromContext(ctx context.Context) *zerolog.Logger {
+	return zerolog.Ctx(ctx)
+}
+
+// detachedContext keeps the values of its parent but not its cancellation.
+type detachedContext struct {
+	parent context.Context
+}
+
+func detach(ctx context.Context) context.Context {
+	return detachedContext{parent: ctx}
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
+
+func (e *eventRouter) bindContext(ctx context.Context) {
+	e.ctxLock.Lock()
+	defer e.ctxLock.Unlock()
+	e.runCtx = ctx
+}
+
+func (e *eventRouter) baseContext() context.Context {
+	e.ctxLock.RLock()
+	defer e.ctxLock.RUnlock()
+	if e.runCtx == nil {
+		return context.Background()
+	}
+	return e.runCtx
+}
+
+func (e *eventRouter) eventContext(meta EventMetadata) (context.Context, context.CancelFunc) {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if e.eventTimeout > 0 {
+		ctx, cancel = context.WithTimeout(e.baseContext(), e.eventTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(e.baseContext())
+	}
+
+	l := e.log.With().Str("event", meta.Name).Logger()
+	if meta.Shard >= 0 {
+		l = l.With().Int("shard", meta.Shard).Logger()
+	}
+	if meta.Sequence > 0 {
+		l = l.With().Uint64("sequence", meta.Sequence).Logger()
+	}
+
+	ctx = l.WithContext(ctx)
+	ctx = context.WithValue(ctx, metadataKey{}, meta)
+
+	return ctx, cancel
+}
@@ -0,0 +1,218 @@
+package receiver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"wumpgo.dev/wumpgo/objects"
+)
+
+var (
+	_ DedupStore = (*MemoryDedupStore)(nil)
+	_ DedupStore = (*RedisDedupStore)(nil)
+)
+
+// DedupStore remembers which events were already routed.
+type DedupStore interface {
+	// Seen marks key as seen for ttl and reports whether it had already been
+	// seen.
+	Seen(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// Forget removes key, so that an event that failed to be handled isn't
+	// dropped when it is delivered again.
+	Forget(ctx context.Context, key string) error
+}
+
+// DedupKeyFunc returns a stable identity for an event. Events it returns
+// false for are never deduplicated.
+type DedupKeyFunc func(meta EventMetadata, data json.RawMessage) (string, bool)
+
+// DedupConfig configures event deduplication.
+type DedupConfig struct {
+	// Store keeps track of seen events. Use a RedisDedupStore to deduplicate
+	// across receivers sharing a consumer group. Defaults to a
+	// MemoryDedupStore.
+	Store DedupStore
+	// TTL is how long an event is remembered. Defaults to 5 minutes.
+	TTL time.Duration
+	// Key identifies events. Defaults to SequenceDedupKey.
+	Key DedupKeyFunc
+}
+
+// repeatableEvents can be sent twice with the same payload without being
+// duplicates, e.g. a reaction that is added, removed and added again.
+var repeatableEvents = map[string]struct{}{
+	"MESSAGE_REACTION_ADD":          {},
+	"MESSAGE_REACTION_REMOVE":       {},
+	"MESSAGE_REACTION_REMOVE_ALL":   {},
+	"MESSAGE_REACTION_REMOVE_EMOJI": {},
+	"GUILD_MEMBER_UPDATE":           {},
+	"PRESENCE_UPDATE":               {},
+	"VOICE_STATE_UPDATE":            {},
+}
+
+// SequenceDedupKey identifies an event by the shard, session and sequence it
+// was received with, so only the same delivery of an event is deduplicated.
+// Events whose transport didn't carry those are not deduplicated.
+func SequenceDedupKey(meta EventMetadata, _ json.RawMessage) (string, bool) {
+	if meta.Session == "" || meta.Sequence == 0 {
+		return "", false
+	}
+	return fmt.Sprintf("%d:%s:%d", meta.Shard, meta.Session, meta.Sequence), true
+}
+
+// ContentDedupKey identifies an event by its name, its own snowflake if it
+// has one and a hash of its data. Unlike SequenceDedupKey it also matches an
+// event received by two shards or sessions, such as while resharding or
+// resuming, and events from transports without a sequence.
+//
+// It drops events that legitimately repeat within the TTL though, such as a
+// member that is banned, unbanned and banned again, or an update that is
+// reverted. Events known to repeat, such as reactions, fall back to
+// SequenceDedupKey, for others it is up to the caller to opt in.
+func ContentDedupKey(meta EventMetadata, data json.RawMessage) (string, bool) {
+	if _, ok := repeatableEvents[meta.Name]; ok {
+		return SequenceDedupKey(meta, data)
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:16])
+
+	var p struct {
+		ID objects.Snowflake `json:"id"`
+	}
+	if err := json.Unmarshal(data, &p); err == nil && p.ID != 0 {
+		return fmt.Sprintf("%s:%s:%s", meta.Name, p.ID, hash), true
+	}
+
+	return fmt.Sprintf("%s:%s", meta.Name, hash), true
+}
+
+// claim marks an event as seen and returns its key, or reports that it was
+// already routed. Errors from the store are logged and the event is handled
+// anyway.
+func (e *eventRouter) claim(meta EventMetadata, data json.RawMessage) (key string, duplicate bool) {
+	conf := e.dedup
+	if conf == nil {
+		return "", false
+	}
+
+	keyFn := conf.Key
+	if keyFn == nil {
+		keyFn = SequenceDedupKey
+	}
+
+	key, ok := keyFn(meta, data)
+	if !ok {
+		return "", false
+	}
+
+	ttl := conf.TTL
+	if ttl <= 0 {
+		ttl = 5 * time.Minute
+	}
+
+	seen, err := conf.Store.Seen(e.baseContext(), key, ttl)
+	if err != nil {
+		e.log.Warn().Err(err).Str("event", meta.Name).Msg("failed to check for duplicate event")
+		return "", false
+	}
+
+	if seen {
+		e.log.Debug().Str("event", meta.Name).Str("key", key).Msg("dropping duplicate event")
+		return "", true
+	}
+
+	return key, false
+}
+
+// release forgets a claimed event that wasn't handled, so it is handled when
+// it is delivered again.
+func (e *eventRouter) release(key string) {
+	if key == "" {
+		return
+	}
+
+	// The receiver may be stopping, the key must be released regardless.
+	ctx, cancel := context.WithTimeout(detach(e.baseContext()), 5*time.Second)
+	defer cancel()
+
+	if err := e.dedup.Store.Forget(ctx, key); err != nil {
+		e.log.Warn().Err(err).Str("key", key).Msg("failed to release deduplication key")
+	}
+}
+
+// MemoryDedupStore keeps seen events in memory. It only deduplicates events
+// routed by the same receiver.
+type MemoryDedupStore struct {
+	lock   sync.Mutex
+	seen   map[string]time.Time
+	nextGC time.Time
+}
+
+func NewMemoryDedupStore() *MemoryDedupStore {
+	return &MemoryDedupStore{
+		seen: make(map[string]time.Time),
+	}
+}
+
+func (m *MemoryDedupStore) Seen(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := time.Now()
+
+	if now.After(m.nextGC) {
+		for k, expires := range m.seen {
+			if now.After(expires) {
+				delete(m.seen, k)
+			}
+		}
+		m.nextGC = now.Add(ttl)
+	}
+
+	if expires, ok := m.seen[key]; ok && !now.After(expires) {
+		return true, nil
+	}
+
+	m.seen[key] = now.Add(ttl)
+	return false, nil
+}
+
+func (m *MemoryDedupStore) Forget(_ context.Context, key string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.seen, key)
+	return nil
+}
+
+// RedisDedupStore keeps seen events in Redis, so receivers sharing a consumer
+// group or a load balancer don't handle the same event twice.
+type RedisDedupStore struct {
+	rdb    *redis.Client
+	prefix string
+}
+
+// NewRedisDedupStore creates a store whose keys are prefixed with prefix,
+// e.g. "wumpgo:dedup:".
+func NewRedisDedupStore(rdb *redis.Client, prefix string) *RedisDedupStore {
+	return &RedisDedupStore{rdb: rdb, prefix: prefix}
+}
+
+func (r *RedisDedupStore) Seen(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	set, err := r.rdb.SetNX(ctx, r.prefix+key, 1, ttl).Result()
+	if err != nil {
+		return false, err
+	}
+	return !set, nil
+}
+
+func (r *RedisDedupStore) Forget(ctx context.Context, key string) error {
+	return r.rdb.Del(ctx, r.prefix+key).Err()
+}
@@ -0,0 +1,201 @@
+package receiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"wumpgo.dev/wumpgo/objects"
+	"wumpgo.dev/wumpgo/rest"
+)
+
+func TestContentDedupKey(t *testing.T) {
+	resumed := EventMetadata{Name: "MESSAGE_CREATE", Shard: 0, Session: "a", Sequence: 10}
+	resharded := EventMetadata{Name: "MESSAGE_CREATE", Shard: 3, Session: "b", Sequence: 2}
+	message := []byte(`{"id":"5","content":"hi"}`)
+
+	tests := []struct {
+		name      string
+		a, b      EventMetadata
+		dataA     []byte
+		dataB     []byte
+		wantSame  bool
+		wantNoKey bool
+	}{
+		{name: "redelivered", a: resumed, b: resumed, dataA: message, dataB: message, wantSame: true},
+		{name: "other shard and session", a: resumed, b: resharded, dataA: message, dataB: message, wantSame: true},
+		{name: "unknown origin", a: UnknownMetadata(), b: resharded, dataA: message, dataB: message, wantSame: true},
+		{name: "edited", a: resumed, b: resumed, dataA: message, dataB: []byte(`{"id":"5","content":"edited"}`)},
+		{
+			name:     "no id",
+			a:        EventMetadata{Name: "TYPING_START"},
+			b:        EventMetadata{Name: "TYPING_START"},
+			dataA:    []byte(`{"user_id":"1","timestamp":1}`),
+			dataB:    []byte(`{"user_id":"1","timestamp":1}`),
+			wantSame: true,
+		},
+		{
+			name:  "reaction added again",
+			a:     EventMetadata{Name: "MESSAGE_REACTION_ADD", Session: "a", Sequence: 1},
+			b:     EventMetadata{Name: "MESSAGE_REACTION_ADD", Session: "a", Sequence: 3},
+			dataA: []byte(`{"user_id":"1","message_id":"2"}`),
+			dataB: []byte(`{"user_id":"1","message_id":"2"}`),
+		},
+		{
+			name:      "reaction without sequence",
+			a:         EventMetadata{Name: "MESSAGE_REACTION_ADD"},
+			dataA:     []byte(`{"user_id":"1","message_id":"2"}`),
+			wantNoKey: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.a.Name == "" {
+				tt.a.Name = "MESSAGE_CREATE"
+			}
+			if tt.b.Name == "" {
+				tt.b.Name = tt.a.Name
+			}
+
+			a, ok := ContentDedupKey(tt.a, tt.dataA)
+			if tt.wantNoKey {
+				require.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+
+			b, ok := ContentDedupKey(tt.b, tt.dataB)
+			require.True(t, ok)
+			require.Equal(t, tt.wantSame, a == b, "%s / %s", a, b)
+		})
+	}
+}
+
+func TestDeduplication_Redelivery(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+	stores := map[string]func() DedupStore{
+		"memory": func() DedupStore { return NewMemoryDedupStore() },
+		"redis": func() DedupStore {
+			mr.FlushAll()
+			return NewRedisDedupStore(rdb, "test:dedup:")
+		},
+	}
+
+	tests := []struct {
+		name        string
+		fail        bool
+		queueFull   bool
+		wantHandled int
+	}{
+		{name: "handled", wantHandled: 1},
+		{name: "handler failed", fail: true, wantHandled: 2},
+		{name: "queue full", queueFull: true, wantHandled: 1},
+	}
+
+	for storeName, newStore := range stores {
+		for _, tt := range tests {
+			t.Run(storeName+"/"+tt.name, func(t *testing.T) {
+				opts := []ReceiverOption{WithDeduplication(DedupConfig{Store: newStore()})}
+				if tt.queueFull {
+					opts = append(opts, WithWorkerPool(WorkerPoolConfig{
+						Workers:   1,
+						QueueSize: 1,
+						Policy:    QueuePolicyDrop,
+					}))
+				}
+				r := NewLocalReceiver(opts...)
+
+				handled := 0
+				_, err := r.On(func(_ context.Context, _ rest.RESTClient, m *objects.MessageCreate) error {
+					handled++
+					if tt.fail && handled == 1 {
+						return errors.New("database is down")
+					}
+					return nil
+				})
+				require.NoError(t, err)
+
+				meta := EventMetadata{Shard: 0, Session: "a", Sequence: 7}
+				event := []byte(`{"id":"5","content":"hi"}`)
+
+				if tt.queueFull {
+					// Fill the queue of a pool that isn't running yet so the
+					// event is rejected, then deliver it again once it runs.
+					require.NoError(t, r.Route("MESSAGE_CREATE", []byte(`{"id":"4"}`)))
+					require.ErrorIs(t, r.RouteWithMetadata(meta, "MESSAGE_CREATE", event), ErrQueueFull)
+					stop := runReceiver(t, r)
+					require.Eventually(t, func() bool {
+						return r.RouteWithMetadata(meta, "MESSAGE_CREATE", event) == nil
+					}, time.Second, 10*time.Millisecond)
+					require.NoError(t, r.RouteWithMetadata(meta, "MESSAGE_CREATE", event))
+					require.NoError(t, stop())
+					// One for the filler event, one for the redelivered one.
+					require.Equal(t, tt.wantHandled+1, handled)
+					return
+				}
+
+				err = r.RouteWithMetadata(meta, "MESSAGE_CREATE", event)
+				if tt.fail {
+					require.Error(t, err)
+				} else {
+					require.NoError(t, err)
+				}
+				require.NoError(t, r.RouteWithMetadata(meta, "MESSAGE_CREATE", event))
+				require.NoError(t, r.RouteWithMetadata(meta, "MESSAGE_CREATE", event))
+				require.Equal(t, tt.wantHandled, handled)
+			})
+		}
+	}
+}
+
+func TestMemoryDedupStore(t *testing.T) {
+	s := NewMemoryDedupStore()
+	ctx := context.Background()
+
+	seen, err := s.Seen(ctx, "a", time.Minute)
+	require.NoError(t, err)
+	require.False(t, seen)
+
+	seen, err = s.Seen(ctx, "a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, seen)
+
+	require.NoError(t, s.Forget(ctx, "a"))
+	seen, err = s.Seen(ctx, "a", time.Minute)
+	require.NoError(t, err)
+	require.False(t, seen)
+
+	seen, err = s.Seen(ctx, "b", -time.Second)
+	require.NoError(t, err)
+	require.False(t, seen)
+	seen, err = s.Seen(ctx, "b", time.Minute)
+	require.NoError(t, err)
+	require.False(t, seen)
+}
+
+func TestDeduplication_RepeatedEvents(t *testing.T) {
+	r := NewLocalReceiver(WithDeduplication(DedupConfig{}))
+
+	bans := 0
+	_, err := r.On(func(_ context.Context, _ rest.RESTClient, b *objects.GuildBanAdd) error {
+		bans++
+		return nil
+	})
+	require.NoError(t, err)
+
+	// A member that is banned, unbanned and banned again gets the same
+	// payload twice, only redeliveries of the same event are dropped.
+	ban := []byte(`{"guild_id":"1","user":{"id":"2"}}`)
+	require.NoError(t, r.RouteWithMetadata(EventMetadata{Session: "a", Sequence: 1}, "GUILD_BAN_ADD", ban))
+	require.NoError(t, r.RouteWithMetadata(EventMetadata{Session: "a", Sequence: 2}, "GUILD_BAN_REMOVE", ban))
+	require.NoError(t, r.RouteWithMetadata(EventMetadata{Session: "a", Sequence: 3}, "GUILD_BAN_ADD", ban))
+	require.NoError(t, r.RouteWithMetadata(EventMetadata{Session: "a", Sequence: 3}, "GUILD_BAN_ADD", ban))
+	require.Equal(t, 2, bans)
+}
@@ -2,6 +2,12 @@ package receiver
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
 
 	"wumpgo.dev/wumpgo/rest"
 )
@@ -10,7 +16,7 @@ import (
 
 type EventHandlerIface interface {
 	New() interface{}
-	Handle(context.Context, rest.RESTClient, interface{})
+	Handle(context.Context, rest.RESTClient, interface{}) error
 }
 
 type EventHandler[T any] func(context.Context, rest.RESTClient, *T)
@@ -20,12 +26,187 @@ func (eh EventHandler[T]) New() interface{} {
 	return &obj
 }
 
-func (eh EventHandler[T]) Handle(ctx context.Context, c rest.RESTClient, i interface{}) {
+func (eh EventHandler[T]) Handle(ctx context.Context, c rest.RESTClient, i interface{}) error {
 	if t, ok := i.(*T); ok {
 		eh(ctx, c, t)
 	}
+	return nil
 }
 
 func newHandler[T any](v EventHandler[T]) EventHandler[T] {
 	return v
 }
+
+// ErrorEventHandler is an EventHandler that reports failures, which are
+// retried according to the receiver's RetryPolicy.
+type ErrorEventHandler[T any] func(context.Context, rest.RESTClient, *T) error
+
+func (eh ErrorEventHandler[T]) New() interface{} {
+	var obj T
+	return &obj
+}
+
+func (eh ErrorEventHandler[T]) Handle(ctx context.Context, c rest.RESTClient, i interface{}) error {
+	if t, ok := i.(*T); ok {
+		return eh(ctx, c, t)
+	}
+	return nil
+}
+
+func newErrorHandler[T any](v ErrorEventHandler[T]) ErrorEventHandler[T] {
+	return v
+}
+
+// RawEventHandler receives events without decoding them, including events
+// the library has no type for. event is the gateway event name, e.g.
+// "MESSAGE_CREATE".
+type RawEventHandler func(ctx context.Context, c rest.RESTClient, event string, raw json.RawMessage)
+
+func (eh RawEventHandler) New() interface{} {
+	return &json.RawMessage{}
+}
+
+func (eh RawEventHandler) Handle(ctx context.Context, c rest.RESTClient, i interface{}) error {
+	if raw, ok := i.(*json.RawMessage); ok {
+		eh(ctx, c, EventNameFromContext(ctx), *raw)
+	}
+	return nil
+}
+
+// RawErrorEventHandler is a RawEventHandler that reports failures.
+type RawErrorEventHandler func(ctx context.Context, c rest.RESTClient, event string, raw json.RawMessage) error
+
+func (eh RawErrorEventHandler) New() interface{} {
+	return &json.RawMessage{}
+}
+
+func (eh RawErrorEventHandler) Handle(ctx context.Context, c rest.RESTClient, i interface{}) error {
+	if raw, ok := i.(*json.RawMessage); ok {
+		return eh(ctx, c, EventNameFromContext(ctx), *raw)
+	}
+	return nil
+}
+
+func rawEventHandler(h HandlerFunc) (EventHandlerIface, bool) {
+	switch v := h.(type) {
+	case func(context.Context, rest.RESTClient, string, json.RawMessage):
+		return RawEventHandler(v), true
+	case func(context.Context, rest.RESTClient, string, json.RawMessage) error:
+		return RawErrorEventHandler(v), true
+	case RawEventHandler:
+		return v, true
+	case RawErrorEventHandler:
+		return v, true
+	}
+	return nil, false
+}
+
+// PayloadType returns the payload type of a gateway event, e.g.
+// objects.MessageCreate for MESSAGE_CREATE.
+func PayloadType(event string) (reflect.Type, bool) {
+	t, ok := eventTypes[strings.ToUpper(event)]
+	return t, ok
+}
+
+// NewPayload returns a pointer to a new payload of a gateway event, e.g. a
+// *objects.MessageCreate for MESSAGE_CREATE.
+func NewPayload(event string) (interface{}, bool) {
+	t, ok := PayloadType(event)
+	if !ok {
+		return nil, false
+	}
+	return reflect.New(t).Interface(), true
+}
+
+// EventNames returns the names of all gateway events that have a payload
+// type, in alphabetical order.
+func EventNames() []string {
+	names := make([]string, 0, len(eventTypes))
+	for name := range eventTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type customEvent struct {
+	name    string
+	handler func(HandlerFunc) (EventHandlerIface, bool)
+}
+
+var (
+	customEventsLock sync.RWMutex
+	customEvents     = make(map[reflect.Type]customEvent)
+)
+
+// RegisterEvent registers an event that isn't sent by the gateway, e.g. one
+// routed by another package with Route. Afterwards handlers taking a *T, with
+// or without an error result, can be registered with On and Once, and T can
+// be used with WaitFor and Collect. The payload is decoded from JSON like the
+// payload of a gateway event.
+//
+// Registering the same event again is a no-op. name must not be a gateway
+// event and T must not be the payload type of another event.
+func RegisterEvent[T any](name string) error {
+	name = strings.ToLower(name)
+	if name == "" || strings.ContainsAny(name, ".*?[\\") {
+		return fmt.Errorf("invalid event name %q", name)
+	}
+	if _, ok := PayloadType(name); ok {
+		return fmt.Errorf("%s is a gateway event", strings.ToUpper(name))
+	}
+
+	t := reflect.TypeOf((*T)(nil))
+	for _, gt := range eventTypes {
+		if t.Elem() == gt {
+			return fmt.Errorf("%s is the payload of a gateway event", t)
+		}
+	}
+
+	customEventsLock.Lock()
+	defer customEventsLock.Unlock()
+
+	for ct, ev := range customEvents {
+		switch {
+		case ct == t && ev.name == name:
+			return nil
+		case ct == t:
+			return fmt.Errorf("%s is already registered for %s", t, strings.ToUpper(ev.name))
+		case ev.name == name:
+			return fmt.Errorf("%s is already registered for %s", strings.ToUpper(name), ct)
+		}
+	}
+
+	customEvents[t] = customEvent{
+		name: name,
+		handler: func(h HandlerFunc) (EventHandlerIface, bool) {
+			switch v := h.(type) {
+			case func(context.Context, rest.RESTClient, *T):
+				return newHandler(v), true
+			case func(context.Context, rest.RESTClient, *T) error:
+				return newErrorHandler(v), true
+			}
+			return nil, false
+		},
+	}
+	return nil
+}
+
+// customEventHandler finds the event registered with RegisterEvent for the
+// payload type of h.
+func customEventHandler(h HandlerFunc) (EventHandlerIface, string, bool) {
+	t := reflect.TypeOf(h)
+	if t == nil || t.Kind() != reflect.Func || t.NumIn() != 3 {
+		return nil, "", false
+	}
+
+	customEventsLock.RLock()
+	ev, ok := customEvents[t.In(2)]
+	customEventsLock.RUnlock()
+	if !ok {
+		return nil, "", false
+	}
+
+	eh, ok := ev.handler(h)
+	return eh, ev.name, ok
+}
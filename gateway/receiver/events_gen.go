@@ -4,133 +4,745 @@ package receiver
 import (
 	"context"
 	"fmt"
+	"reflect"
 	objects "wumpgo.dev/wumpgo/objects"
 	rest "wumpgo.dev/wumpgo/rest"
 )
 
+const (
+	EventReady                               = "READY"
+	EventApplicationCommandPermissionsUpdate = "APPLICATION_COMMAND_PERMISSIONS_UPDATE"
+	EventAutoModerationRuleCreate            = "AUTO_MODERATION_RULE_CREATE"
+	EventAutoModerationRuleUpdate            = "AUTO_MODERATION_RULE_UPDATE"
+	EventAutoModerationRuleDelete            = "AUTO_MODERATION_RULE_DELETE"
+	EventAutoModerationActionExecution       = "AUTO_MODERATION_ACTION_EXECUTION"
+	EventChannelCreate                       = "CHANNEL_CREATE"
+	EventChannelUpdate                       = "CHANNEL_UPDATE"
+	EventChannelDelete                       = "CHANNEL_DELETE"
+	EventChannelPinsUpdate                   = "CHANNEL_PINS_UPDATE"
+	EventThreadCreate                        = "THREAD_CREATE"
+	EventThreadUpdate                        = "THREAD_UPDATE"
+	EventThreadDelete                        = "THREAD_DELETE"
+	EventThreadListSync                      = "THREAD_LIST_SYNC"
+	EventThreadMemberUpdate                  = "THREAD_MEMBER_UPDATE"
+	EventThreadMembersUpdate                 = "THREAD_MEMBERS_UPDATE"
+	EventGuildCreate                         = "GUILD_CREATE"
+	EventGuildUpdate                         = "GUILD_UPDATE"
+	EventGuildDelete                         = "GUILD_DELETE"
+	EventGuildAuditLogEntryCreate            = "GUILD_AUDIT_LOG_ENTRY_CREATE"
+	EventGuildBanAdd                         = "GUILD_BAN_ADD"
+	EventGuildBanRemove                      = "GUILD_BAN_REMOVE"
+	EventGuildEmojisUpdate                   = "GUILD_EMOJIS_UPDATE"
+	EventGuildStickersUpdate                 = "GUILD_STICKERS_UPDATE"
+	EventGuildIntegrationsUpdate             = "GUILD_INTEGRATIONS_UPDATE"
+	EventGuildMemberAdd                      = "GUILD_MEMBER_ADD"
+	EventGuildMemberRemove                   = "GUILD_MEMBER_REMOVE"
+	EventGuildMemberUpdate                   = "GUILD_MEMBER_UPDATE"
+	EventGuildMembersChunk                   = "GUILD_MEMBERS_CHUNK"
+	EventGuildRoleCreate                     = "GUILD_ROLE_CREATE"
+	EventGuildRoleUpdate                     = "GUILD_ROLE_UPDATE"
+	EventGuildRoleDelete                     = "GUILD_ROLE_DELETE"
+	EventGuildScheduledEventCreate           = "GUILD_SCHEDULED_EVENT_CREATE"
+	EventGuildScheduledEventUpdate           = "GUILD_SCHEDULED_EVENT_UPDATE"
+	EventGuildScheduledEventDelete           = "GUILD_SCHEDULED_EVENT_DELETE"
+	EventGuildScheduledEventUserAdd          = "GUILD_SCHEDULED_EVENT_USER_ADD"
+	EventGuildScheduledEventUserRemove       = "GUILD_SCHEDULED_EVENT_USER_REMOVE"
+	EventIntegrationCreate                   = "INTEGRATION_CREATE"
+	EventIntegrationUpdate                   = "INTEGRATION_UPDATE"
+	EventIntegrationDelete                   = "INTEGRATION_DELETE"
+	EventInviteCreate                        = "INVITE_CREATE"
+	EventInviteDelete                        = "INVITE_DELETE"
+	EventMessageCreate                       = "MESSAGE_CREATE"
+	EventMessageUpdate                       = "MESSAGE_UPDATE"
+	EventMessageDelete                       = "MESSAGE_DELETE"
+	EventMessageDeleteBulk                   = "MESSAGE_DELETE_BULK"
+	EventMessageReactionAdd                  = "MESSAGE_REACTION_ADD"
+	EventMessageReactionRemove               = "MESSAGE_REACTION_REMOVE"
+	EventMessageReactionRemoveAll            = "MESSAGE_REACTION_REMOVE_ALL"
+	EventMessageReactionRemoveEmoji          = "MESSAGE_REACTION_REMOVE_EMOJI"
+	EventPresenceUpdate                      = "PRESENCE_UPDATE"
+	EventTypingStart                         = "TYPING_START"
+	EventUserUpdate                          = "USER_UPDATE"
+	EventVoiceStateUpdate                    = "VOICE_STATE_UPDATE"
+	EventVoiceServerUpdate                   = "VOICE_SERVER_UPDATE"
+	EventWebhooksUpdate                      = "WEBHOOKS_UPDATE"
+	EventInteractionCreate                   = "INTERACTION_CREATE"
+	EventStageInstanceCreate                 = "STAGE_INSTANCE_CREATE"
+	EventStageInstanceUpdate                 = "STAGE_INSTANCE_UPDATE"
+	EventStageInstanceDelete                 = "STAGE_INSTANCE_DELETE"
+)
+
+var eventTypes = map[string]reflect.Type{
+	EventApplicationCommandPermissionsUpdate: reflect.TypeOf(objects.ApplicationCommandPermissionsUpdate{}),
+	EventAutoModerationActionExecution:       reflect.TypeOf(objects.AutoModerationActionExecution{}),
+	EventAutoModerationRuleCreate:            reflect.TypeOf(objects.AutoModerationRuleCreate{}),
+	EventAutoModerationRuleDelete:            reflect.TypeOf(objects.AutoModerationRuleDelete{}),
+	EventAutoModerationRuleUpdate:            reflect.TypeOf(objects.AutoModerationRuleUpdate{}),
+	EventChannelCreate:                       reflect.TypeOf(objects.ChannelCreate{}),
+	EventChannelDelete:                       reflect.TypeOf(objects.ChannelDelete{}),
+	EventChannelPinsUpdate:                   reflect.TypeOf(objects.ChannelPinsUpdate{}),
+	EventChannelUpdate:                       reflect.TypeOf(objects.ChannelUpdate{}),
+	EventGuildAuditLogEntryCreate:            reflect.TypeOf(objects.GuildAuditLogEntryCreate{}),
+	EventGuildBanAdd:                         reflect.TypeOf(objects.GuildBanAdd{}),
+	EventGuildBanRemove:                      reflect.TypeOf(objects.GuildBanRemove{}),
+	EventGuildCreate:                         reflect.TypeOf(objects.GuildCreate{}),
+	EventGuildDelete:                         reflect.TypeOf(objects.GuildDelete{}),
+	EventGuildEmojisUpdate:                   reflect.TypeOf(objects.GuildEmojisUpdate{}),
+	EventGuildIntegrationsUpdate:             reflect.TypeOf(objects.GuildIntegrationsUpdate{}),
+	EventGuildMemberAdd:                      reflect.TypeOf(objects.GuildMemberAdd{}),
+	EventGuildMemberRemove:                   reflect.TypeOf(objects.GuildMemberRemove{}),
+	EventGuildMemberUpdate:                   reflect.TypeOf(objects.GuildMemberUpdate{}),
+	EventGuildMembersChunk:                   reflect.TypeOf(objects.GuildMembersChunk{}),
+	EventGuildRoleCreate:                     reflect.TypeOf(objects.GuildRoleCreate{}),
+	EventGuildRoleDelete:                     reflect.TypeOf(objects.GuildRoleDelete{}),
+	EventGuildRoleUpdate:                     reflect.TypeOf(objects.GuildRoleUpdate{}),
+	EventGuildScheduledEventCreate:           reflect.TypeOf(objects.GuildScheduledEventCreate{}),
+	EventGuildScheduledEventDelete:           reflect.TypeOf(objects.GuildScheduledEventDelete{}),
+	EventGuildScheduledEventUpdate:           reflect.TypeOf(objects.GuildScheduledEventUpdate{}),
+	EventGuildScheduledEventUserAdd:          reflect.TypeOf(objects.GuildScheduledEventUserAdd{}),
+	EventGuildScheduledEventUserRemove:       reflect.TypeOf(objects.GuildScheduledEventUserRemove{}),
+	EventGuildStickersUpdate:                 reflect.TypeOf(objects.GuildStickersUpdate{}),
+	EventGuildUpdate:                         reflect.TypeOf(objects.GuildUpdate{}),
+	EventIntegrationCreate:                   reflect.TypeOf(objects.IntegrationCreate{}),
+	EventIntegrationDelete:                   reflect.TypeOf(objects.IntegrationDelete{}),
+	EventIntegrationUpdate:                   reflect.TypeOf(objects.IntegrationUpdate{}),
+	EventInteractionCreate:                   reflect.TypeOf(objects.InteractionCreate{}),
+	EventInviteCreate:                        reflect.TypeOf(objects.InviteCreate{}),
+	EventInviteDelete:                        reflect.TypeOf(objects.InviteDelete{}),
+	EventMessageCreate:                       reflect.TypeOf(objects.MessageCreate{}),
+	EventMessageDelete:                       reflect.TypeOf(objects.MessageDelete{}),
+	EventMessageDeleteBulk:                   reflect.TypeOf(objects.MessageDeleteBulk{}),
+	EventMessageReactionAdd:                  reflect.TypeOf(objects.MessageReactionAdd{}),
+	EventMessageReactionRemove:               reflect.TypeOf(objects.MessageReactionRemove{}),
+	EventMessageReactionRemoveAll:            reflect.TypeOf(objects.MessageReactionRemoveAll{}),
+	EventMessageReactionRemoveEmoji:          reflect.TypeOf(objects.MessageReactionRemoveEmoji{}),
+	EventMessageUpdate:                       reflect.TypeOf(objects.MessageUpdate{}),
+	EventPresenceUpdate:                      reflect.TypeOf(objects.PresenceUpdate{}),
+	EventReady:                               reflect.TypeOf(objects.Ready{}),
+	EventStageInstanceCreate:                 reflect.TypeOf(objects.StageInstanceCreate{}),
+	EventStageInstanceDelete:                 reflect.TypeOf(objects.StageInstanceDelete{}),
+	EventStageInstanceUpdate:                 reflect.TypeOf(objects.StageInstanceUpdate{}),
+	EventThreadCreate:                        reflect.TypeOf(objects.ThreadCreate{}),
+	EventThreadDelete:                        reflect.TypeOf(objects.ThreadDelete{}),
+	EventThreadListSync:                      reflect.TypeOf(objects.ThreadListSync{}),
+	EventThreadMemberUpdate:                  reflect.TypeOf(objects.ThreadMemberUpdate{}),
+	EventThreadMembersUpdate:                 reflect.TypeOf(objects.ThreadMembersUpdate{}),
+	EventThreadUpdate:                        reflect.TypeOf(objects.ThreadUpdate{}),
+	EventTypingStart:                         reflect.TypeOf(objects.TypingStart{}),
+	EventUserUpdate:                          reflect.TypeOf(objects.UserUpdate{}),
+	EventVoiceServerUpdate:                   reflect.TypeOf(objects.VoiceServerUpdate{}),
+	EventVoiceStateUpdate:                    reflect.TypeOf(objects.VoiceStateUpdate{}),
+	EventWebhooksUpdate:                      reflect.TypeOf(objects.WebhooksUpdate{}),
+}
+
 func eventHandlerToEvent(h HandlerFunc) (EventHandlerIface, string, error) {
 	switch v := h.(type) {
 	case func(context.Context, rest.RESTClient, *objects.Ready):
 		return newHandler(v), "ready", nil
+	case func(context.Context, rest.RESTClient, *objects.Ready) error:
+		return newErrorHandler(v), "ready", nil
 	case func(context.Context, rest.RESTClient, *objects.ApplicationCommandPermissionsUpdate):
 		return newHandler(v), "application_command_permissions_update", nil
+	case func(context.Context, rest.RESTClient, *objects.ApplicationCommandPermissionsUpdate) error:
+		return newErrorHandler(v), "application_command_permissions_update", nil
 	case func(context.Context, rest.RESTClient, *objects.AutoModerationRuleCreate):
 		return newHandler(v), "auto_moderation_rule_create", nil
+	case func(context.Context, rest.RESTClient, *objects.AutoModerationRuleCreate) error:
+		return newErrorHandler(v), "auto_moderation_rule_create", nil
 	case func(context.Context, rest.RESTClient, *objects.AutoModerationRuleUpdate):
 		return newHandler(v), "auto_moderation_rule_update", nil
+	case func(context.Context, rest.RESTClient, *objects.AutoModerationRuleUpdate) error:
+		return newErrorHandler(v), "auto_moderation_rule_update", nil
 	case func(context.Context, rest.RESTClient, *objects.AutoModerationRuleDelete):
 		return newHandler(v), "auto_moderation_rule_delete", nil
+	case func(context.Context, rest.RESTClient, *objects.AutoModerationRuleDelete) error:
+		return newErrorHandler(v), "auto_moderation_rule_delete", nil
 	case func(context.Context, rest.RESTClient, *objects.AutoModerationActionExecution):
 		return newHandler(v), "auto_moderation_action_execution", nil
+	case func(context.Context, rest.RESTClient, *objects.AutoModerationActionExecution) error:
+		return newErrorHandler(v), "auto_moderation_action_execution", nil
 	case func(context.Context, rest.RESTClient, *objects.ChannelCreate):
 		return newHandler(v), "channel_create", nil
+	case func(context.Context, rest.RESTClient, *objects.ChannelCreate) error:
+		return newErrorHandler(v), "channel_create", nil
 	case func(context.Context, rest.RESTClient, *objects.ChannelUpdate):
 		return newHandler(v), "channel_update", nil
+	case func(context.Context, rest.RESTClient, *objects.ChannelUpdate) error:
+		return newErrorHandler(v), "channel_update", nil
 	case func(context.Context, rest.RESTClient, *objects.ChannelDelete):
 		return newHandler(v), "channel_delete", nil
+	case func(context.Context, rest.RESTClient, *objects.ChannelDelete) error:
+		return newErrorHandler(v), "channel_delete", nil
 	case func(context.Context, rest.RESTClient, *objects.ChannelPinsUpdate):
 		return newHandler(v), "channel_pins_update", nil
+	case func(context.Context, rest.RESTClient, *objects.ChannelPinsUpdate) error:
+		return newErrorHandler(v), "channel_pins_update", nil
 	case func(context.Context, rest.RESTClient, *objects.ThreadCreate):
 		return newHandler(v), "thread_create", nil
+	case func(context.Context, rest.RESTClient, *objects.ThreadCreate) error:
+		return newErrorHandler(v), "thread_create", nil
 	case func(context.Context, rest.RESTClient, *objects.ThreadUpdate):
 		return newHandler(v), "thread_update", nil
+	case func(context.Context, rest.RESTClient, *objects.ThreadUpdate) error:
+		return newErrorHandler(v), "thread_update", nil
 	case func(context.Context, rest.RESTClient, *objects.ThreadDelete):
 		return newHandler(v), "thread_delete", nil
+	case func(context.Context, rest.RESTClient, *objects.ThreadDelete) error:
+		return newErrorHandler(v), "thread_delete", nil
 	case func(context.Context, rest.RESTClient, *objects.ThreadListSync):
 		return newHandler(v), "thread_list_sync", nil
+	case func(context.Context, rest.RESTClient, *objects.ThreadListSync) error:
+		return newErrorHandler(v), "thread_list_sync", nil
 	case func(context.Context, rest.RESTClient, *objects.ThreadMemberUpdate):
 		return newHandler(v), "thread_member_update", nil
+	case func(context.Context, rest.RESTClient, *objects.ThreadMemberUpdate) error:
+		return newErrorHandler(v), "thread_member_update", nil
 	case func(context.Context, rest.RESTClient, *objects.ThreadMembersUpdate):
 		return newHandler(v), "thread_members_update", nil
+	case func(context.Context, rest.RESTClient, *objects.ThreadMembersUpdate) error:
+		return newErrorHandler(v), "thread_members_update", nil
 	case func(context.Context, rest.RESTClient, *objects.GuildCreate):
 		return newHandler(v), "guild_create", nil
+	case func(context.Context, rest.RESTClient, *objects.GuildCreate) error:
+		return newErrorHandler(v), "guild_create", nil
 	case func(context.Context, rest.RESTClient, *objects.GuildUpdate):
 		return newHandler(v), "guild_update", nil
+	case func(context.Context, rest.RESTClient, *objects.GuildUpdate) error:
+		return newErrorHandler(v), "guild_update", nil
 	case func(context.Context, rest.RESTClient, *objects.GuildDelete):
 		return newHandler(v), "guild_delete", nil
+	case func(context.Context, rest.RESTClient, *objects.GuildDelete) error:
+		return newErrorHandler(v), "guild_delete", nil
 	case func(context.Context, rest.RESTClient, *objects.GuildAuditLogEntryCreate):
 		return newHandler(v), "guild_audit_log_entry_create", nil
+	case func(context.Context, rest.RESTClient, *objects.GuildAuditLogEntryCreate) error:
+		return newErrorHandler(v), "guild_audit_log_entry_create", nil
 	case func(context.Context, rest.RESTClient, *objects.GuildBanAdd):
 		return newHandler(v), "guild_ban_add", nil
+	case func(context.Context, rest.RESTClient, *objects.GuildBanAdd) error:
+		return newErrorHandler(v), "guild_ban_add", nil
 	case func(context.Context, rest.RESTClient, *objects.GuildBanRemove):
 		return newHandler(v), "guild_ban_remove", nil
+	case func(context.Context, rest.RESTClient, *objects.GuildBanRemove) error:
+		return newErrorHandler(v), "guild_ban_remove", nil
 	case func(context.Context, rest.RESTClient, *objects.GuildEmojisUpdate):
 		return newHandler(v), "guild_emojis_update", nil
+	case func(context.Context, rest.RESTClient, *objects.GuildEmojisUpdate) error:
+		return newErrorHandler(v), "guild_emojis_update", nil
 	case func(context.Context, rest.RESTClient, *objects.GuildStickersUpdate):
 		return newHandler(v), "guild_stickers_update", nil
+	case func(context.Context, rest.RESTClient, *objects.GuildStickersUpdate) error:
+		return newErrorHandler(v), "guild_stickers_update", nil
 	case func(context.Context, rest.RESTClient, *objects.GuildIntegrationsUpdate):
 		return newHandler(v), "guild_integrations_update", nil
+	case func(context.Context, rest.RESTClient, *objects.GuildIntegrationsUpdate) error:
+		return newErrorHandler(v), "guild_integrations_update", nil
 	case func(context.Context, rest.RESTClient, *objects.GuildMemberAdd):
 		return newHandler(v), "guild_member_add", nil
+	case func(context.Context, rest.RESTClient, *objects.GuildMemberAdd) error:
+		return newErrorHandler(v), "guild_member_add", nil
 	case func(context.Context, rest.RESTClient, *objects.GuildMemberRemove):
 		return newHandler(v), "guild_member_remove", nil
+	case func(context.Context, rest.RESTClient, *objects.GuildMemberRemove) error:
+		return newErrorHandler(v), "guild_member_remove", nil
 	case func(context.Context, rest.RESTClient, *objects.GuildMemberUpdate):
 		return newHandler(v), "guild_member_update", nil
+	case func(context.Context, rest.RESTClient, *objects.GuildMemberUpdate) error:
+		return newErrorHandler(v), "guild_member_update", nil
 	case func(context.Context, rest.RESTClient, *objects.GuildMembersChunk):
 		return newHandler(v), "guild_members_chunk", nil
+	case func(context.Context, rest.RESTClient, *objects.GuildMembersChunk) error:
+		return newErrorHandler(v), "guild_members_chunk", nil
 	case func(context.Context, rest.RESTClient, *objects.GuildRoleCreate):
 		return newHandler(v), "guild_role_create", nil
+	case func(context.Context, rest.RESTClient, *objects.GuildRoleCreate) error:
+		return newErrorHandler(v), "guild_role_create", nil
 	case func(context.Context, rest.RESTClient, *objects.GuildRoleUpdate):
 		return newHandler(v), "guild_role_update", nil
+	case func(context.Context, rest.RESTClient, *objects.GuildRoleUpdate) error:
+		return newErrorHandler(v), "guild_role_update", nil
 	case func(context.Context, rest.RESTClient, *objects.GuildRoleDelete):
 		return newHandler(v), "guild_role_delete", nil
+	case func(context.Context, rest.RESTClient, *objects.GuildRoleDelete) error:
+		return newErrorHandler(v), "guild_role_delete", nil
 	case func(context.Context, rest.RESTClient, *objects.GuildScheduledEventCreate):
 		return newHandler(v), "guild_scheduled_event_create", nil
+	case func(context.Context, rest.RESTClient, *objects.GuildScheduledEventCreate) error:
+		return newErrorHandler(v), "guild_scheduled_event_create", nil
 	case func(context.Context, rest.RESTClient, *objects.GuildScheduledEventUpdate):
 		return newHandler(v), "guild_scheduled_event_update", nil
+	case func(context.Context, rest.RESTClient, *objects.GuildScheduledEventUpdate) error:
+		return newErrorHandler(v), "guild_scheduled_event_update", nil
 	case func(context.Context, rest.RESTClient, *objects.GuildScheduledEventDelete):
 		return newHandler(v), "guild_scheduled_event_delete", nil
+	case func(context.Context, rest.RESTClient, *objects.GuildScheduledEventDelete) error:
+		return newErrorHandler(v), "guild_scheduled_event_delete", nil
 	case func(context.Context, rest.RESTClient, *objects.GuildScheduledEventUserAdd):
 		return newHandler(v), "guild_scheduled_event_user_add", nil
+	case func(context.Context, rest.RESTClient, *objects.GuildScheduledEventUserAdd) error:
+		return newErrorHandler(v), "guild_scheduled_event_user_add", nil
 	case func(context.Context, rest.RESTClient, *objects.GuildScheduledEventUserRemove):
 		return newHandler(v), "guild_scheduled_event_user_remove", nil
+	case func(context.Context, rest.RESTClient, *objects.GuildScheduledEventUserRemove) error:
+		return newErrorHandler(v), "guild_scheduled_event_user_remove", nil
 	case func(context.Context, rest.RESTClient, *objects.IntegrationCreate):
 		return newHandler(v), "integration_create", nil
+	case func(context.Context, rest.RESTClient, *objects.IntegrationCreate) error:
+		return newErrorHandler(v), "integration_create", nil
 	case func(context.Context, rest.RESTClient, *objects.IntegrationUpdate):
 		return newHandler(v), "integration_update", nil
+	case func(context.Context, rest.RESTClient, *objects.IntegrationUpdate) error:
+		return newErrorHandler(v), "integration_update", nil
 	case func(context.Context, rest.RESTClient, *objects.IntegrationDelete):
 		return newHandler(v), "integration_delete", nil
+	case func(context.Context, rest.RESTClient, *objects.IntegrationDelete) error:
+		return newErrorHandler(v), "integration_delete", nil
 	case func(context.Context, rest.RESTClient, *objects.InviteCreate):
 		return newHandler(v), "invite_create", nil
+	case func(context.Context, rest.RESTClient, *objects.InviteCreate) error:
+		return newErrorHandler(v), "invite_create", nil
 	case func(context.Context, rest.RESTClient, *objects.InviteDelete):
 		return newHandler(v), "invite_delete", nil
+	case func(context.Context, rest.RESTClient, *objects.InviteDelete) error:
+		return newErrorHandler(v), "invite_delete", nil
 	case func(context.Context, rest.RESTClient, *objects.MessageCreate):
 		return newHandler(v), "message_create", nil
+	case func(context.Context, rest.RESTClient, *objects.MessageCreate) error:
+		return newErrorHandler(v), "message_create", nil
 	case func(context.Context, rest.RESTClient, *objects.MessageUpdate):
 		return newHandler(v), "message_update", nil
+	case func(context.Context, rest.RESTClient, *objects.MessageUpdate) error:
+		return newErrorHandler(v), "message_update", nil
 	case func(context.Context, rest.RESTClient, *objects.MessageDelete):
 		return newHandler(v), "message_delete", nil
+	case func(context.Context, rest.RESTClient, *objects.MessageDelete) error:
+		return newErrorHandler(v), "message_delete", nil
 	case func(context.Context, rest.RESTClient, *objects.MessageDeleteBulk):
 		return newHandler(v), "message_delete_bulk", nil
+	case func(context.Context, rest.RESTClient, *objects.MessageDeleteBulk) error:
+		return newErrorHandler(v), "message_delete_bulk", nil
 	case func(context.Context, rest.RESTClient, *objects.MessageReactionAdd):
 		return newHandler(v), "message_reaction_add", nil
+	case func(context.Context, rest.RESTClient, *objects.MessageReactionAdd) error:
+		return newErrorHandler(v), "message_reaction_add", nil
 	case func(context.Context, rest.RESTClient, *objects.MessageReactionRemove):
 		return newHandler(v), "message_reaction_remove", nil
+	case func(context.Context, rest.RESTClient, *objects.MessageReactionRemove) error:
+		return newErrorHandler(v), "message_reaction_remove", nil
 	case func(context.Context, rest.RESTClient, *objects.MessageReactionRemoveAll):
 		return newHandler(v), "message_reaction_remove_all", nil
+	case func(context.Context, rest.RESTClient, *objects.MessageReactionRemoveAll) error:
+		return newErrorHandler(v), "message_reaction_remove_all", nil
 	case func(context.Context, rest.RESTClient, *objects.MessageReactionRemoveEmoji):
 		return newHandler(v), "message_reaction_remove_emoji", nil
+	case func(context.Context, rest.RESTClient, *objects.MessageReactionRemoveEmoji) error:
+		return newErrorHandler(v), "message_reaction_remove_emoji", nil
 	case func(context.Context, rest.RESTClient, *objects.PresenceUpdate):
 		return newHandler(v), "presence_update", nil
+	case func(context.Context, rest.RESTClient, *objects.PresenceUpdate) error:
+		return newErrorHandler(v), "presence_update", nil
 	case func(context.Context, rest.RESTClient, *objects.TypingStart):
 		return newHandler(v), "typing_start", nil
+	case func(context.Context, rest.RESTClient, *objects.TypingStart) error:
+		return newErrorHandler(v), "typing_start", nil
 	case func(context.Context, rest.RESTClient, *objects.UserUpdate):
 		return newHandler(v), "user_update", nil
+	case func(context.Context, rest.RESTClient, *objects.UserUpdate) error:
+		return newErrorHandler(v), "user_update", nil
 	case func(context.Context, rest.RESTClient, *objects.VoiceStateUpdate):
 		return newHandler(v), "voice_state_update", nil
+	case func(context.Context, rest.RESTClient, *objects.VoiceStateUpdate) error:
+		return newErrorHandler(v), "voice_state_update", nil
 	case func(context.Context, rest.RESTClient, *objects.VoiceServerUpdate):
 		return newHandler(v), "voice_server_update", nil
+	case func(context.Context, rest.RESTClient, *objects.VoiceServerUpdate) error:
+		return newErrorHandler(v), "voice_server_update", nil
 	case func(context.Context, rest.RESTClient, *objects.WebhooksUpdate):
 		return newHandler(v), "webhooks_update", nil
+	case func(context.Context, rest.RESTClient, *objects.WebhooksUpdate) error:
+		return newErrorHandler(v), "webhooks_update", nil
 	case func(context.Context, rest.RESTClient, *objects.InteractionCreate):
 		return newHandler(v), "interaction_create", nil
+	case func(context.Context, rest.RESTClient, *objects.InteractionCreate) error:
+		return newErrorHandler(v), "interaction_create", nil
 	case func(context.Context, rest.RESTClient, *objects.StageInstanceCreate):
 		return newHandler(v), "stage_instance_create", nil
+	case func(context.Context, rest.RESTClient, *objects.StageInstanceCreate) error:
+		return newErrorHandler(v), "stage_instance_create", nil
 	case func(context.Context, rest.RESTClient, *objects.StageInstanceUpdate):
 		return newHandler(v), "stage_instance_update", nil
+	case func(context.Context, rest.RESTClient, *objects.StageInstanceUpdate) error:
+		return newErrorHandler(v), "stage_instance_update", nil
 	case func(context.Context, rest.RESTClient, *objects.StageInstanceDelete):
 		return newHandler(v), "stage_instance_delete", nil
+	case func(context.Context, rest.RESTClient, *objects.StageInstanceDelete) error:
+		return newErrorHandler(v), "stage_instance_delete", nil
 	default:
 		return nil, "invalid", fmt.Errorf("invalid handler func")
 	}
 }
+
+// EventHandlers registers handlers for a single event type without going
+// through On's type switch. Every Receiver implements it.
+type EventHandlers interface {
+	OnReady(h func(context.Context, rest.RESTClient, *objects.Ready)) *Registration
+	OnApplicationCommandPermissionsUpdate(h func(context.Context, rest.RESTClient, *objects.ApplicationCommandPermissionsUpdate)) *Registration
+	OnAutoModerationRuleCreate(h func(context.Context, rest.RESTClient, *objects.AutoModerationRuleCreate)) *Registration
+	OnAutoModerationRuleUpdate(h func(context.Context, rest.RESTClient, *objects.AutoModerationRuleUpdate)) *Registration
+	OnAutoModerationRuleDelete(h func(context.Context, rest.RESTClient, *objects.AutoModerationRuleDelete)) *Registration
+	OnAutoModerationActionExecution(h func(context.Context, rest.RESTClient, *objects.AutoModerationActionExecution)) *Registration
+	OnChannelCreate(h func(context.Context, rest.RESTClient, *objects.ChannelCreate)) *Registration
+	OnChannelUpdate(h func(context.Context, rest.RESTClient, *objects.ChannelUpdate)) *Registration
+	OnChannelDelete(h func(context.Context, rest.RESTClient, *objects.ChannelDelete)) *Registration
+	OnChannelPinsUpdate(h func(context.Context, rest.RESTClient, *objects.ChannelPinsUpdate)) *Registration
+	OnThreadCreate(h func(context.Context, rest.RESTClient, *objects.ThreadCreate)) *Registration
+	OnThreadUpdate(h func(context.Context, rest.RESTClient, *objects.ThreadUpdate)) *Registration
+	OnThreadDelete(h func(context.Context, rest.RESTClient, *objects.ThreadDelete)) *Registration
+	OnThreadListSync(h func(context.Context, rest.RESTClient, *objects.ThreadListSync)) *Registration
+	OnThreadMemberUpdate(h func(context.Context, rest.RESTClient, *objects.ThreadMemberUpdate)) *Registration
+	OnThreadMembersUpdate(h func(context.Context, rest.RESTClient, *objects.ThreadMembersUpdate)) *Registration
+	OnGuildCreate(h func(context.Context, rest.RESTClient, *objects.GuildCreate)) *Registration
+	OnGuildUpdate(h func(context.Context, rest.RESTClient, *objects.GuildUpdate)) *Registration
+	OnGuildDelete(h func(context.Context, rest.RESTClient, *objects.GuildDelete)) *Registration
+	OnGuildAuditLogEntryCreate(h func(context.Context, rest.RESTClient, *objects.GuildAuditLogEntryCreate)) *Registration
+	OnGuildBanAdd(h func(context.Context, rest.RESTClient, *objects.GuildBanAdd)) *Registration
+	OnGuildBanRemove(h func(context.Context, rest.RESTClient, *objects.GuildBanRemove)) *Registration
+	OnGuildEmojisUpdate(h func(context.Context, rest.RESTClient, *objects.GuildEmojisUpdate)) *Registration
+	OnGuildStickersUpdate(h func(context.Context, rest.RESTClient, *objects.GuildStickersUpdate)) *Registration
+	OnGuildIntegrationsUpdate(h func(context.Context, rest.RESTClient, *objects.GuildIntegrationsUpdate)) *Registration
+	OnGuildMemberAdd(h func(context.Context, rest.RESTClient, *objects.GuildMemberAdd)) *Registration
+	OnGuildMemberRemove(h func(context.Context, rest.RESTClient, *objects.GuildMemberRemove)) *Registration
+	OnGuildMemberUpdate(h func(context.Context, rest.RESTClient, *objects.GuildMemberUpdate)) *Registration
+	OnGuildMembersChunk(h func(context.Context, rest.RESTClient, *objects.GuildMembersChunk)) *Registration
+	OnGuildRoleCreate(h func(context.Context, rest.RESTClient, *objects.GuildRoleCreate)) *Registration
+	OnGuildRoleUpdate(h func(context.Context, rest.RESTClient, *objects.GuildRoleUpdate)) *Registration
+	OnGuildRoleDelete(h func(context.Context, rest.RESTClient, *objects.GuildRoleDelete)) *Registration
+	OnGuildScheduledEventCreate(h func(context.Context, rest.RESTClient, *objects.GuildScheduledEventCreate)) *Registration
+	OnGuildScheduledEventUpdate(h func(context.Context, rest.RESTClient, *objects.GuildScheduledEventUpdate)) *Registration
+	OnGuildScheduledEventDelete(h func(context.Context, rest.RESTClient, *objects.GuildScheduledEventDelete)) *Registration
+	OnGuildScheduledEventUserAdd(h func(context.Context, rest.RESTClient, *objects.GuildScheduledEventUserAdd)) *Registration
+	OnGuildScheduledEventUserRemove(h func(context.Context, rest.RESTClient, *objects.GuildScheduledEventUserRemove)) *Registration
+	OnIntegrationCreate(h func(context.Context, rest.RESTClient, *objects.IntegrationCreate)) *Registration
+	OnIntegrationUpdate(h func(context.Context, rest.RESTClient, *objects.IntegrationUpdate)) *Registration
+	OnIntegrationDelete(h func(context.Context, rest.RESTClient, *objects.IntegrationDelete)) *Registration
+	OnInviteCreate(h func(context.Context, rest.RESTClient, *objects.InviteCreate)) *Registration
+	OnInviteDelete(h func(context.Context, rest.RESTClient, *objects.InviteDelete)) *Registration
+	OnMessageCreate(h func(context.Context, rest.RESTClient, *objects.MessageCreate)) *Registration
+	OnMessageUpdate(h func(context.Context, rest.RESTClient, *objects.MessageUpdate)) *Registration
+	OnMessageDelete(h func(context.Context, rest.RESTClient, *objects.MessageDelete)) *Registration
+	OnMessageDeleteBulk(h func(context.Context, rest.RESTClient, *objects.MessageDeleteBulk)) *Registration
+	OnMessageReactionAdd(h func(context.Context, rest.RESTClient, *objects.MessageReactionAdd)) *Registration
+	OnMessageReactionRemove(h func(context.Context, rest.RESTClient, *objects.MessageReactionRemove)) *Registration
+	OnMessageReactionRemoveAll(h func(context.Context, rest.RESTClient, *objects.MessageReactionRemoveAll)) *Registration
+	OnMessageReactionRemoveEmoji(h func(context.Context, rest.RESTClient, *objects.MessageReactionRemoveEmoji)) *Registration
+	OnPresenceUpdate(h func(context.Context, rest.RESTClient, *objects.PresenceUpdate)) *Registration
+	OnTypingStart(h func(context.Context, rest.RESTClient, *objects.TypingStart)) *Registration
+	OnUserUpdate(h func(context.Context, rest.RESTClient, *objects.UserUpdate)) *Registration
+	OnVoiceStateUpdate(h func(context.Context, rest.RESTClient, *objects.VoiceStateUpdate)) *Registration
+	OnVoiceServerUpdate(h func(context.Context, rest.RESTClient, *objects.VoiceServerUpdate)) *Registration
+	OnWebhooksUpdate(h func(context.Context, rest.RESTClient, *objects.WebhooksUpdate)) *Registration
+	OnInteractionCreate(h func(context.Context, rest.RESTClient, *objects.InteractionCreate)) *Registration
+	OnStageInstanceCreate(h func(context.Context, rest.RESTClient, *objects.StageInstanceCreate)) *Registration
+	OnStageInstanceUpdate(h func(context.Context, rest.RESTClient, *objects.StageInstanceUpdate)) *Registration
+	OnStageInstanceDelete(h func(context.Context, rest.RESTClient, *objects.StageInstanceDelete)) *Registration
+}
+
+// OnReady registers a handler for READY events.
+func (e *eventRouter) OnReady(h func(context.Context, rest.RESTClient, *objects.Ready)) *Registration {
+	return e.add("ready", newHandler(h), false)
+}
+
+// OnApplicationCommandPermissionsUpdate registers a handler for APPLICATION_COMMAND_PERMISSIONS_UPDATE events.
+func (e *eventRouter) OnApplicationCommandPermissionsUpdate(h func(context.Context, rest.RESTClient, *objects.ApplicationCommandPermissionsUpdate)) *Registration {
+	return e.add("application_command_permissions_update", newHandler(h), false)
+}
+
+// OnAutoModerationRuleCreate registers a handler for AUTO_MODERATION_RULE_CREATE events.
+func (e *eventRouter) OnAutoModerationRuleCreate(h func(context.Context, rest.RESTClient, *objects.AutoModerationRuleCreate)) *Registration {
+	return e.add("auto_moderation_rule_create", newHandler(h), false)
+}
+
+// OnAutoModerationRuleUpdate registers a handler for AUTO_MODERATION_RULE_UPDATE events.
+func (e *eventRouter) OnAutoModerationRuleUpdate(h func(context.Context, rest.RESTClient, *objects.AutoModerationRuleUpdate)) *Registration {
+	return e.add("auto_moderation_rule_update", newHandler(h), false)
+}
+
+// OnAutoModerationRuleDelete registers a handler for AUTO_MODERATION_RULE_DELETE events.
+func (e *eventRouter) OnAutoModerationRuleDelete(h func(context.Context, rest.RESTClient, *objects.AutoModerationRuleDelete)) *Registration {
+	return e.add("auto_moderation_rule_delete", newHandler(h), false)
+}
+
+// OnAutoModerationActionExecution registers a handler for AUTO_MODERATION_ACTION_EXECUTION events.
+func (e *eventRouter) OnAutoModerationActionExecution(h func(context.Context, rest.RESTClient, *objects.AutoModerationActionExecution)) *Registration {
+	return e.add("auto_moderation_action_execution", newHandler(h), false)
+}
+
+// OnChannelCreate registers a handler for CHANNEL_CREATE events.
+func (e *eventRouter) OnChannelCreate(h func(context.Context, rest.RESTClient, *objects.ChannelCreate)) *Registration {
+	return e.add("channel_create", newHandler(h), false)
+}
+
+// OnChannelUpdate registers a handler for CHANNEL_UPDATE events.
+func (e *eventRouter) OnChannelUpdate(h func(context.Context, rest.RESTClient, *objects.ChannelUpdate)) *Registration {
+	return e.add("channel_update", newHandler(h), false)
+}
+
+// OnChannelDelete registers a handler for CHANNEL_DELETE events.
+func (e *eventRouter) OnChannelDelete(h func(context.Context, rest.RESTClient, *objects.ChannelDelete)) *Registration {
+	return e.add("channel_delete", newHandler(h), false)
+}
+
+// OnChannelPinsUpdate registers a handler for CHANNEL_PINS_UPDATE events.
+func (e *eventRouter) OnChannelPinsUpdate(h func(context.Context, rest.RESTClient, *objects.ChannelPinsUpdate)) *Registration {
+	return e.add("channel_pins_update", newHandler(h), false)
+}
+
+// OnThreadCreate registers a handler for THREAD_CREATE events.
+func (e *eventRouter) OnThreadCreate(h func(context.Context, rest.RESTClient, *objects.ThreadCreate)) *Registration {
+	return e.add("thread_create", newHandler(h), false)
+}
+
+// OnThreadUpdate registers a handler for THREAD_UPDATE events.
+func (e *eventRouter) OnThreadUpdate(h func(context.Context, rest.RESTClient, *objects.ThreadUpdate)) *Registration {
+	return e.add("thread_update", newHandler(h), false)
+}
+
+// OnThreadDelete registers a handler for THREAD_DELETE events.
+func (e *eventRouter) OnThreadDelete(h func(context.Context, rest.RESTClient, *objects.ThreadDelete)) *Registration {
+	return e.add("thread_delete", newHandler(h), false)
+}
+
+// OnThreadListSync registers a handler for THREAD_LIST_SYNC events.
+func (e *eventRouter) OnThreadListSync(h func(context.Context, rest.RESTClient, *objects.ThreadListSync)) *Registration {
+	return e.add("thread_list_sync", newHandler(h), false)
+}
+
+// OnThreadMemberUpdate registers a handler for THREAD_MEMBER_UPDATE events.
+func (e *eventRouter) OnThreadMemberUpdate(h func(context.Context, rest.RESTClient, *objects.ThreadMemberUpdate)) *Registration {
+	return e.add("thread_member_update", newHandler(h), false)
+}
+
+// OnThreadMembersUpdate registers a handler for THREAD_MEMBERS_UPDATE events.
+func (e *eventRouter) OnThreadMembersUpdate(h func(context.Context, rest.RESTClient, *objects.ThreadMembersUpdate)) *Registration {
+	return e.add("thread_members_update", newHandler(h), false)
+}
+
+// OnGuildCreate registers a handler for GUILD_CREATE events.
+func (e *eventRouter) OnGuildCreate(h func(context.Context, rest.RESTClient, *objects.GuildCreate)) *Registration {
+	return e.add("guild_create", newHandler(h), false)
+}
+
+// OnGuildUpdate registers a handler for GUILD_UPDATE events.
+func (e *eventRouter) OnGuildUpdate(h func(context.Context, rest.RESTClient, *objects.GuildUpdate)) *Registration {
+	return e.add("guild_update", newHandler(h), false)
+}
+
+// OnGuildDelete registers a handler for GUILD_DELETE events.
+func (e *eventRouter) OnGuildDelete(h func(context.Context, rest.RESTClient, *objects.GuildDelete)) *Registration {
+	return e.add("guild_delete", newHandler(h), false)
+}
+
+// OnGuildAuditLogEntryCreate registers a handler for GUILD_AUDIT_LOG_ENTRY_CREATE events.
+func (e *eventRouter) OnGuildAuditLogEntryCreate(h func(context.Context, rest.RESTClient, *objects.GuildAuditLogEntryCreate)) *Registration {
+	return e.add("guild_audit_log_entry_create", newHandler(h), false)
+}
+
+// OnGuildBanAdd registers a handler for GUILD_BAN_ADD events.
+func (e *eventRouter) OnGuildBanAdd(h func(context.Context, rest.RESTClient, *objects.GuildBanAdd)) *Registration {
+	return e.add("guild_ban_add", newHandler(h), false)
+}
+
+// OnGuildBanRemove registers a handler for GUILD_BAN_REMOVE events.
+func (e *eventRouter) OnGuildBanRemove(h func(context.Context, rest.RESTClient, *objects.GuildBanRemove)) *Registration {
+	return e.add("guild_ban_remove", newHandler(h), false)
+}
+
+// OnGuildEmojisUpdate registers a handler for GUILD_EMOJIS_UPDATE events.
+func (e *eventRouter) OnGuildEmojisUpdate(h func(context.Context, rest.RESTClient, *objects.GuildEmojisUpdate)) *Registration {
+	return e.add("guild_emojis_update", newHandler(h), false)
+}
+
+// OnGuildStickersUpdate registers a handler for GUILD_STICKERS_UPDATE events.
+func (e *eventRouter) OnGuildStickersUpdate(h func(context.Context, rest.RESTClient, *objects.GuildStickersUpdate)) *Registration {
+	return e.add("guild_stickers_update", newHandler(h), false)
+}
+
+// OnGuildIntegrationsUpdate registers a handler for GUILD_INTEGRATIONS_UPDATE events.
+func (e *eventRouter) OnGuildIntegrationsUpdate(h func(context.Context, rest.RESTClient, *objects.GuildIntegrationsUpdate)) *Registration {
+	return e.add("guild_integrations_update", newHandler(h), false)
+}
+
+// OnGuildMemberAdd registers a handler for GUILD_MEMBER_ADD events.
+func (e *eventRouter) OnGuildMemberAdd(h func(context.Context, rest.RESTClient, *objects.GuildMemberAdd)) *Registration {
+	return e.add("guild_member_add", newHandler(h), false)
+}
+
+// OnGuildMemberRemove registers a handler for GUILD_MEMBER_REMOVE events.
+func (e *eventRouter) OnGuildMemberRemove(h func(context.Context, rest.RESTClient, *objects.GuildMemberRemove)) *Registration {
+	return e.add("guild_member_remove", newHandler(h), false)
+}
+
+// OnGuildMemberUpdate registers a handler for GUILD_MEMBER_UPDATE events.
+func (e *eventRouter) OnGuildMemberUpdate(h func(context.Context, rest.RESTClient, *objects.GuildMemberUpdate)) *Registration {
+	return e.add("guild_member_update", newHandler(h), false)
+}
+
+// OnGuildMembersChunk registers a handler for GUILD_MEMBERS_CHUNK events.
+func (e *eventRouter) OnGuildMembersChunk(h func(context.Context, rest.RESTClient, *objects.GuildMembersChunk)) *Registration {
+	return e.add("guild_members_chunk", newHandler(h), false)
+}
+
+// OnGuildRoleCreate registers a handler for GUILD_ROLE_CREATE events.
+func (e *eventRouter) OnGuildRoleCreate(h func(context.Context, rest.RESTClient, *objects.GuildRoleCreate)) *Registration {
+	return e.add("guild_role_create", newHandler(h), false)
+}
+
+// OnGuildRoleUpdate registers a handler for GUILD_ROLE_UPDATE events.
+func (e *eventRouter) OnGuildRoleUpdate(h func(context.Context, rest.RESTClient, *objects.GuildRoleUpdate)) *Registration {
+	return e.add("guild_role_update", newHandler(h), false)
+}
+
+// OnGuildRoleDelete registers a handler for GUILD_ROLE_DELETE events.
+func (e *eventRouter) OnGuildRoleDelete(h func(context.Context, rest.RESTClient, *objects.GuildRoleDelete)) *Registration {
+	return e.add("guild_role_delete", newHandler(h), false)
+}
+
+// OnGuildScheduledEventCreate registers a handler for GUILD_SCHEDULED_EVENT_CREATE events.
+func (e *eventRouter) OnGuildScheduledEventCreate(h func(context.Context, rest.RESTClient, *objects.GuildScheduledEventCreate)) *Registration {
+	return e.add("guild_scheduled_event_create", newHandler(h), false)
+}
+
+// OnGuildScheduledEventUpdate registers a handler for GUILD_SCHEDULED_EVENT_UPDATE events.
+func (e *eventRouter) OnGuildScheduledEventUpdate(h func(context.Context, rest.RESTClient, *objects.GuildScheduledEventUpdate)) *Registration {
+	return e.add("guild_scheduled_event_update", newHandler(h), false)
+}
+
+// OnGuildScheduledEventDelete registers a handler for GUILD_SCHEDULED_EVENT_DELETE events.
+func (e *eventRouter) OnGuildScheduledEventDelete(h func(context.Context, rest.RESTClient, *objects.GuildScheduledEventDelete)) *Registration {
+	return e.add("guild_scheduled_event_delete", newHandler(h), false)
+}
+
+// OnGuildScheduledEventUserAdd registers a handler for GUILD_SCHEDULED_EVENT_USER_ADD events.
+func (e *eventRouter) OnGuildScheduledEventUserAdd(h func(context.Context, rest.RESTClient, *objects.GuildScheduledEventUserAdd)) *Registration {
+	return e.add("guild_scheduled_event_user_add", newHandler(h), false)
+}
+
+// OnGuildScheduledEventUserRemove registers a handler for GUILD_SCHEDULED_EVENT_USER_REMOVE events.
+func (e *eventRouter) OnGuildScheduledEventUserRemove(h func(context.Context, rest.RESTClient, *objects.GuildScheduledEventUserRemove)) *Registration {
+	return e.add("guild_scheduled_event_user_remove", newHandler(h), false)
+}
+
+// OnIntegrationCreate registers a handler for INTEGRATION_CREATE events.
+func (e *eventRouter) OnIntegrationCreate(h func(context.Context, rest.RESTClient, *objects.IntegrationCreate)) *Registration {
+	return e.add("integration_create", newHandler(h), false)
+}
+
+// OnIntegrationUpdate registers a handler for INTEGRATION_UPDATE events.
+func (e *eventRouter) OnIntegrationUpdate(h func(context.Context, rest.RESTClient, *objects.IntegrationUpdate)) *Registration {
+	return e.add("integration_update", newHandler(h), false)
+}
+
+// OnIntegrationDelete registers a handler for INTEGRATION_DELETE events.
+func (e *eventRouter) OnIntegrationDelete(h func(context.Context, rest.RESTClient, *objects.IntegrationDelete)) *Registration {
+	return e.add("integration_delete", newHandler(h), false)
+}
+
+// OnInviteCreate registers a handler for INVITE_CREATE events.
+func (e *eventRouter) OnInviteCreate(h func(context.Context, rest.RESTClient, *objects.InviteCreate)) *Registration {
+	return e.add("invite_create", newHandler(h), false)
+}
+
+// OnInviteDelete registers a handler for INVITE_DELETE events.
+func (e *eventRouter) OnInviteDelete(h func(context.Context, rest.RESTClient, *objects.InviteDelete)) *Registration {
+	return e.add("invite_delete", newHandler(h), false)
+}
+
+// OnMessageCreate registers a handler for MESSAGE_CREATE events.
+func (e *eventRouter) OnMessageCreate(h func(context.Context, rest.RESTClient, *objects.MessageCreate)) *Registration {
+	return e.add("message_create", newHandler(h), false)
+}
+
+// OnMessageUpdate registers a handler for MESSAGE_UPDATE events.
+func (e *eventRouter) OnMessageUpdate(h func(context.Context, rest.RESTClient, *objects.MessageUpdate)) *Registration {
+	return e.add("message_update", newHandler(h), false)
+}
+
+// OnMessageDelete registers a handler for MESSAGE_DELETE events.
+func (e *eventRouter) OnMessageDelete(h func(context.Context, rest.RESTClient, *objects.MessageDelete)) *Registration {
+	return e.add("message_delete", newHandler(h), false)
+}
+
+// OnMessageDeleteBulk registers a handler for MESSAGE_DELETE_BULK events.
+func (e *eventRouter) OnMessageDeleteBulk(h func(context.Context, rest.RESTClient, *objects.MessageDeleteBulk)) *Registration {
+	return e.add("message_delete_bulk", newHandler(h), false)
+}
+
+// OnMessageReactionAdd registers a handler for MESSAGE_REACTION_ADD events.
+func (e *eventRouter) OnMessageReactionAdd(h func(context.Context, rest.RESTClient, *objects.MessageReactionAdd)) *Registration {
+	return e.add("message_reaction_add", newHandler(h), false)
+}
+
+// OnMessageReactionRemove registers a handler for MESSAGE_REACTION_REMOVE events.
+func (e *eventRouter) OnMessageReactionRemove(h func(context.Context, rest.RESTClient, *objects.MessageReactionRemove)) *Registration {
+	return e.add("message_reaction_remove", newHandler(h), false)
+}
+
+// OnMessageReactionRemoveAll registers a handler for MESSAGE_REACTION_REMOVE_ALL events.
+func (e *eventRouter) OnMessageReactionRemoveAll(h func(context.Context, rest.RESTClient, *objects.MessageReactionRemoveAll)) *Registration {
+	return e.add("message_reaction_remove_all", newHandler(h), false)
+}
+
+// OnMessageReactionRemoveEmoji registers a handler for MESSAGE_REACTION_REMOVE_EMOJI events.
+func (e *eventRouter) OnMessageReactionRemoveEmoji(h func(context.Context, rest.RESTClient, *objects.MessageReactionRemoveEmoji)) *Registration {
+	return e.add("message_reaction_remove_emoji", newHandler(h), false)
+}
+
+// OnPresenceUpdate registers a handler for PRESENCE_UPDATE events.
+func (e *eventRouter) OnPresenceUpdate(h func(context.Context, rest.RESTClient, *objects.PresenceUpdate)) *Registration {
+	return e.add("presence_update", newHandler(h), false)
+}
+
+// OnTypingStart registers a handler for TYPING_START events.
+func (e *eventRouter) OnTypingStart(h func(context.Context, rest.RESTClient, *objects.TypingStart)) *Registration {
+	return e.add("typing_start", newHandler(h), false)
+}
+
+// OnUserUpdate registers a handler for USER_UPDATE events.
+func (e *eventRouter) OnUserUpdate(h func(context.Context, rest.RESTClient, *objects.UserUpdate)) *Registration {
+	return e.add("user_update", newHandler(h), false)
+}
+
+// OnVoiceStateUpdate registers a handler for VOICE_STATE_UPDATE events.
+func (e *eventRouter) OnVoiceStateUpdate(h func(context.Context, rest.RESTClient, *objects.VoiceStateUpdate)) *Registration {
+	return e.add("voice_state_update", newHandler(h), false)
+}
+
+// OnVoiceServerUpdate registers a handler for VOICE_SERVER_UPDATE events.
+func (e *eventRouter) OnVoiceServerUpdate(h func(context.Context, rest.RESTClient, *objects.VoiceServerUpdate)) *Registration {
+	return e.add("voice_server_update", newHandler(h), false)
+}
+
+// OnWebhooksUpdate registers a handler for WEBHOOKS_UPDATE events.
+func (e *eventRouter) OnWebhooksUpdate(h func(context.Context, rest.RESTClient, *objects.WebhooksUpdate)) *Registration {
+	return e.add("webhooks_update", newHandler(h), false)
+}
+
+// OnInteractionCreate registers a handler for INTERACTION_CREATE events.
+func (e *eventRouter) OnInteractionCreate(h func(context.Context, rest.RESTClient, *objects.InteractionCreate)) *Registration {
+	return e.add("interaction_create", newHandler(h), false)
+}
+
+// OnStageInstanceCreate registers a handler for STAGE_INSTANCE_CREATE events.
+func (e *eventRouter) OnStageInstanceCreate(h func(context.Context, rest.RESTClient, *objects.StageInstanceCreate)) *Registration {
+	return e.add("stage_instance_create", newHandler(h), false)
+}
+
+// OnStageInstanceUpdate registers a handler for STAGE_INSTANCE_UPDATE events.
+func (e *eventRouter) OnStageInstanceUpdate(h func(context.Context, rest.RESTClient, *objects.StageInstanceUpdate)) *Registration {
+	return e.add("stage_instance_update", newHandler(h), false)
+}
+
+// OnStageInstanceDelete registers a handler for STAGE_INSTANCE_DELETE events.
+func (e *eventRouter) OnStageInstanceDelete(h func(context.Context, rest.RESTClient, *objects.StageInstanceDelete)) *Registration {
+	return e.add("stage_instance_delete", newHandler(h), false)
+}
@@ -0,0 +1,123 @@
+package receiver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"google.golang.org/grpc"
+	"wumpgo.dev/wumpgo/gateway/internal/eventstream"
+)
+
+var _ Receiver = (*GRPCReceiver)(nil)
+
+// GRPCReceiver consumes the event stream served by a dispatcher.GRPCDispatcher.
+//
+// The receiver subscribes to every event and drops the ones it has no
+// handlers for, so handlers registered while it runs, such as WaitFor's,
+// receive events right away. Use WithGRPCEvents to subscribe to fewer. It acknowledges every event once it has been
+// routed and resumes from the last acknowledged event when the stream is
+// interrupted.
+type GRPCReceiver struct {
+	*eventRouter
+	conn    *grpc.ClientConn
+	epoch   string
+	lastAck uint64
+}
+
+func NewGRPCReceiver(target string, dialOpts []grpc.DialOption, opts ...ReceiverOption) (*GRPCReceiver, error) {
+	dialOpts = append([]grpc.DialOption{
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(eventstream.Codec{})),
+	}, dialOpts...)
+
+	conn, err := grpc.Dial(target, dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	router := newEventRouter(opts...)
+
+	return &GRPCReceiver{conn: conn, eventRouter: router}, nil
+}
+
+func (r *GRPCReceiver) Run(ctx context.Context) error {
+	return r.run(ctx, r.stream)
+}
+
+func (r *GRPCReceiver) stream(ctx context.Context) error {
+	defer r.conn.Close()
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = 0
+
+	for {
+		err := r.receive(ctx, b)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		delay := b.NextBackOff()
+		r.log.Warn().Err(err).Dur("retry_in", delay).Msg("event stream interrupted")
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (r *GRPCReceiver) receive(ctx context.Context, b backoff.BackOff) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := r.conn.NewStream(ctx, eventstream.StreamDesc, eventstream.SubscribePath)
+	if err != nil {
+		return err
+	}
+
+	req := &eventstream.SubscribeRequest{
+		Epoch:      r.epoch,
+		ResumeFrom: r.lastAck,
+		Events:     r.grpcEvents,
+	}
+	if err := stream.SendMsg(req); err != nil {
+		return err
+	}
+
+	md, err := stream.Header()
+	if err != nil {
+		return err
+	}
+
+	epoch := md.Get(eventstream.EpochHeader)
+	if len(epoch) == 0 {
+		return errors.New("event stream sent no epoch")
+	}
+	if epoch[0] != r.epoch {
+		// The dispatcher restarted, so our sequence means nothing to it.
+		r.epoch = epoch[0]
+		r.lastAck = 0
+	}
+
+	r.log.Debug().Str("epoch", r.epoch).Uint64("resume_from", r.lastAck).Msg("subscribed to event stream")
+	b.Reset()
+
+	for {
+		var ev eventstream.Event
+		if err := stream.RecvMsg(&ev); err != nil {
+			return err
+		}
+
+		meta := EventMetadata{Shard: ev.Shard, Sequence: ev.GatewaySequence, Session: ev.Session}
+		if err := r.RouteWithMetadata(meta, ev.Name, ev.Data); err != nil {
+			r.log.Warn().Err(err).Str("event", ev.Name).Msg("failed to route event")
+		}
+
+		r.lastAck = ev.Sequence
+		if err := stream.SendMsg(&eventstream.Ack{Sequence: ev.Sequence}); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package receiver
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"sync/atomic"
+)
+
+// Registration is a handler registered with On, Once or OnRaw. It can be
+// used to remove the handler again.
+type Registration struct {
+	router   *eventRouter
+	event    string
+	wildcard bool
+	handler  EventHandlerIface
+	filter   func(*Partial) bool
+	once     bool
+	fired    atomic.Bool
+}
+
+// Event is the event the handler was registered for, e.g. "message_create",
+// or the pattern for raw handlers.
+func (r *Registration) Event() string {
+	return r.event
+}
+
+// Remove unregisters the handler. It is safe to call more than once.
+func (r *Registration) Remove() {
+	r.router.Off(r)
+}
+
+// claim reports whether the handler should be called. A Once handler is only
+// claimed by the first event that passes its filter and removed afterwards.
+func (r *Registration) claim(p *Partial) bool {
+	if r.filter != nil && !r.filter(p) {
+		return false
+	}
+
+	if !r.once {
+		return true
+	}
+
+	if !r.fired.CompareAndSwap(false, true) {
+		return false
+	}
+
+	r.router.Off(r)
+	return true
+}
+
+func (r *Registration) matches(event string) bool {
+	ok, _ := path.Match(r.event, event)
+	return ok
+}
+
+// On registers a handler. Raw handlers, with the signature
+// func(context.Context, rest.RESTClient, string, json.RawMessage), are called
+// for every event.
+func (e *eventRouter) On(handler HandlerFunc) (*Registration, error) {
+	return e.register("*", handler, false)
+}
+
+// Once registers a handler that is removed after it was called once.
+func (e *eventRouter) Once(handler HandlerFunc) (*Registration, error) {
+	return e.register("*", handler, true)
+}
+
+// OnRaw registers a raw handler for every event whose name matches pattern,
+// e.g. "guild_*" or "message_reaction_*". Patterns use path.Match syntax and
+// are matched case insensitively.
+func (e *eventRouter) OnRaw(pattern string, handler HandlerFunc) (*Registration, error) {
+	if _, ok := rawEventHandler(unwrapFilters(handler)); !ok {
+		return nil, fmt.Errorf("%T is not a raw event handler", handler)
+	}
+
+	pattern = strings.ToLower(pattern)
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid event pattern %q: %w", pattern, err)
+	}
+
+	return e.register(pattern, handler, false)
+}
+
+// Off removes a handler registered with On, Once or OnRaw.
+func (e *eventRouter) Off(reg *Registration) {
+	if reg == nil || reg.router != e {
+		return
+	}
+
+	e.handlersLock.Lock()
+	defer e.handlersLock.Unlock()
+
+	if reg.wildcard {
+		if updated, ok := without(e.wildcards, reg); ok {
+			e.wildcards = updated
+			e.log.Debug().Str("pattern", reg.event).Msg("removed raw handler")
+		}
+		return
+	}
+
+	updated, ok := without(e.handlers[reg.event], reg)
+	if !ok {
+		return
+	}
+
+	if len(updated) == 0 {
+		delete(e.handlers, reg.event)
+	} else {
+		e.handlers[reg.event] = updated
+	}
+
+	e.log.Debug().Str("event", reg.event).Msg("removed handler for event")
+}
+
+func (e *eventRouter) register(pattern string, handler HandlerFunc, once bool) (*Registration, error) {
+	reg := &Registration{once: once}
+
+	for {
+		f, ok := handler.(filteredHandler)
+		if !ok {
+			break
+		}
+		reg.filter = and(reg.filter, f.filter)
+		handler = f.handler
+	}
+
+	if h, ok := rawEventHandler(handler); ok {
+		reg.event = pattern
+		reg.wildcard = true
+		reg.handler = h
+	} else {
+		h, evt, err := eventHandlerToEvent(handler)
+		if err != nil {
+			var ok bool
+			if h, evt, ok = customEventHandler(handler); !ok {
+				return nil, err
+			}
+		}
+		reg.event = evt
+		reg.handler = h
+	}
+
+	return e.addRegistration(reg), nil
+}
+
+// add registers a handler that is known to be valid.
+func (e *eventRouter) add(event string, h EventHandlerIface, once bool) *Registration {
+	return e.addRegistration(&Registration{event: event, handler: h, once: once})
+}
+
+func (e *eventRouter) addRegistration(reg *Registration) *Registration {
+	reg.router = e
+
+	e.handlersLock.Lock()
+	defer e.handlersLock.Unlock()
+
+	if reg.wildcard {
+		e.wildcards = with(e.wildcards, reg)
+		e.log.Debug().Str("pattern", reg.event).Msg("registered raw handler")
+	} else {
+		e.handlers[reg.event] = with(e.handlers[reg.event], reg)
+		e.log.Debug().Str("event", reg.event).Msg("registered handler for event")
+	}
+
+	return reg
+}
+
+// handlersFor returns the handlers registered for an event, followed by the
+// raw handlers matching it. The returned slice must not be modified.
+func (e *eventRouter) handlersFor(event string) []*Registration {
+	e.handlersLock.RLock()
+	defer e.handlersLock.RUnlock()
+
+	regs := e.handlers[event]
+	for _, reg := range e.wildcards {
+		if reg.matches(event) {
+			regs = with(regs, reg)
+		}
+	}
+	return regs
+}
+
+func unwrapFilters(handler HandlerFunc) HandlerFunc {
+	for {
+		f, ok := handler.(filteredHandler)
+		if !ok {
+			return handler
+		}
+		handler = f.handler
+	}
+}
+
+func and(a, b func(*Partial) bool) func(*Partial) bool {
+	if a == nil {
+		return b
+	}
+	return func(p *Partial) bool {
+		return a(p) && b(p)
+	}
+}
+
+// Registration slices are copied on write, jobs that are already queued keep
+// the slice they were created with.
+
+func with(regs []*Registration, reg *Registration) []*Registration {
+	updated := make([]*Registration, len(regs), len(regs)+1)
+	copy(updated, regs)
+	return append(updated, reg)
+}
+
+func without(regs []*Registration, reg *Registration) ([]*Registration, bool) {
+	for i, r := range regs {
+		if r != reg {
+			continue
+		}
+
+		updated := make([]*Registration, 0, len(regs)-1)
+		updated = append(updated, regs[:i]...)
+		return append(updated, regs[i+1:]...), true
+	}
+	return regs, false
+}
@@ -0,0 +1,113 @@
+package receiver
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"wumpgo.dev/wumpgo/objects"
+	"wumpgo.dev/wumpgo/rest"
+)
+
+func TestOnRaw(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		event   string
+		want    bool
+	}{
+		{name: "wildcard", pattern: "*", event: "SOME_NEW_EVENT", want: true},
+		{name: "prefix", pattern: "guild_*", event: "GUILD_ROLE_CREATE", want: true},
+		{name: "case insensitive", pattern: "GUILD_*", event: "guild_role_create", want: true},
+		{name: "no match", pattern: "guild_*", event: "MESSAGE_CREATE"},
+		{name: "channel prefix", pattern: "message_*", event: "discord.MESSAGE_CREATE", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewLocalReceiver()
+
+			var gotEvent string
+			var gotData json.RawMessage
+			_, err := r.OnRaw(tt.pattern, func(ctx context.Context, _ rest.RESTClient, event string, data json.RawMessage) {
+				gotEvent, gotData = event, data
+			})
+			require.NoError(t, err)
+
+			require.NoError(t, r.Route(tt.event, []byte(`{"id":"1"}`)))
+			if !tt.want {
+				require.Empty(t, gotEvent)
+				return
+			}
+			require.Equal(t, strings.ToUpper(strings.TrimPrefix(tt.event, "discord.")), gotEvent)
+			require.JSONEq(t, `{"id":"1"}`, string(gotData))
+		})
+	}
+}
+
+func TestOnRaw_Invalid(t *testing.T) {
+	r := NewLocalReceiver()
+
+	_, err := r.OnRaw("*", func(context.Context, rest.RESTClient, *objects.MessageCreate) {})
+	require.Error(t, err)
+
+	_, err = r.OnRaw("[", func(context.Context, rest.RESTClient, string, json.RawMessage) {})
+	require.Error(t, err)
+}
+
+func TestOn_RawAndTyped(t *testing.T) {
+	r := NewLocalReceiver()
+
+	var calls []string
+	_, err := r.On(func(_ context.Context, _ rest.RESTClient, event string, _ json.RawMessage) error {
+		calls = append(calls, "raw "+event)
+		return nil
+	})
+	require.NoError(t, err)
+	_, err = r.On(func(_ context.Context, _ rest.RESTClient, _ *objects.MessageCreate) {
+		calls = append(calls, "typed")
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, r.Route("MESSAGE_CREATE", messageCreate(1, "")))
+	require.Equal(t, []string{"typed", "raw MESSAGE_CREATE"}, calls)
+}
+
+type testCustomEvent struct {
+	Value string `json:"value"`
+}
+
+type unregisteredEvent struct{}
+
+func TestRegisterEvent(t *testing.T) {
+	require.NoError(t, RegisterEvent[testCustomEvent]("TEST_CUSTOM_EVENT"))
+	require.NoError(t, RegisterEvent[testCustomEvent]("test_custom_event"), "registering again is a no-op")
+
+	require.Error(t, RegisterEvent[testCustomEvent]("TEST_OTHER_EVENT"))
+	require.Error(t, RegisterEvent[unregisteredEvent]("TEST_CUSTOM_EVENT"))
+	require.Error(t, RegisterEvent[unregisteredEvent]("MESSAGE_CREATE"))
+	require.Error(t, RegisterEvent[objects.MessageCreate]("TEST_MESSAGE_CREATE"))
+	require.Error(t, RegisterEvent[unregisteredEvent]("test_*"))
+
+	r := NewLocalReceiver()
+	_, err := r.On(func(context.Context, rest.RESTClient, *unregisteredEvent) {})
+	require.Error(t, err)
+
+	var got []string
+	reg, err := r.On(func(_ context.Context, _ rest.RESTClient, e *testCustomEvent) {
+		got = append(got, e.Value)
+	})
+	require.NoError(t, err)
+	require.Equal(t, "test_custom_event", reg.Event())
+	_, err = r.Once(func(_ context.Context, _ rest.RESTClient, e *testCustomEvent) error {
+		got = append(got, "once "+e.Value)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, r.Route("TEST_CUSTOM_EVENT", []byte(`{"value":"a"}`)))
+	require.NoError(t, r.Route("TEST_CUSTOM_EVENT", []byte(`{"value":"b"}`)))
+	require.Equal(t, []string{"a", "once a", "b"}, got)
+}
@@ -0,0 +1,110 @@
+package receiver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"wumpgo.dev/wumpgo/gateway/internal/webhook"
+)
+
+var (
+	_ Receiver     = (*HTTPReceiver)(nil)
+	_ http.Handler = (*HTTPReceiver)(nil)
+)
+
+const maxHTTPEventSize = 32 << 20
+
+type HTTPReceiverConf struct {
+	// Secret shared with the HTTPDispatcher.
+	Secret []byte
+	// Tolerance is the maximum age of a delivery. Defaults to five minutes,
+	// a negative value disables the check.
+	Tolerance time.Duration
+}
+
+// HTTPReceiver receives events delivered by a dispatcher.HTTPDispatcher.
+//
+// It is an http.Handler that can be mounted in any HTTP server. Deliveries
+// with an invalid signature or a stale timestamp are rejected.
+type HTTPReceiver struct {
+	*eventRouter
+	secret    []byte
+	tolerance time.Duration
+}
+
+func NewHTTPReceiver(conf *HTTPReceiverConf, opts ...ReceiverOption) *HTTPReceiver {
+	tolerance := conf.Tolerance
+	if tolerance == 0 {
+		tolerance = time.Minute * 5
+	}
+
+	router := newEventRouter(opts...)
+
+	return &HTTPReceiver{
+		eventRouter: router,
+		secret:      conf.Secret,
+		tolerance:   tolerance,
+	}
+}
+
+func (r *HTTPReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxHTTPEventSize))
+	if err != nil {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	event := req.Header.Get(webhook.EventHeader)
+	if event == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// The signature covers the metadata headers as well, so they can't be
+	// changed to get a delivery past deduplication.
+	if err := webhook.Verify(r.secret, req.Header, body, r.tolerance); err != nil {
+		r.log.Debug().Err(err).Str("event", event).Msg("rejected event delivery")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	meta := ParseMetadata(req.Header.Get(webhook.ShardHeader), req.Header.Get(webhook.SequenceHeader))
+	meta.Session = req.Header.Get(webhook.SessionHeader)
+	if err := r.RouteWithMetadata(meta, event, body); err != nil {
+		r.log.Warn().Err(err).Str("event", event).Str("delivery", req.Header.Get(webhook.DeliveryHeader)).Msg("failed to route event")
+		w.WriteHeader(routeErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// routeErrorStatus maps a Route error to a status code the HTTPDispatcher
+// only gives up on if delivering the event again can't succeed.
+func routeErrorStatus(err error) int {
+	var malformed *MalformedEventError
+	switch {
+	case errors.As(err, &malformed):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrQueueFull), errors.Is(err, ErrReceiverStopped):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Run blocks until ctx is done. Events are received through ServeHTTP.
+func (r *HTTPReceiver) Run(ctx context.Context) error {
+	return r.run(ctx, func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+}
@@ -0,0 +1,150 @@
+package receiver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"wumpgo.dev/wumpgo/gateway/internal/webhook"
+	"wumpgo.dev/wumpgo/objects"
+	"wumpgo.dev/wumpgo/rest"
+)
+
+func signedRequest(event string, body []byte, headers ...string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	if event != "" {
+		req.Header.Set(webhook.EventHeader, event)
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	req.Header.Set(webhook.TimestampHeader, strconv.FormatInt(time.Now().Unix(), 10))
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign([]byte("secret"), req.Header, body))
+	return req
+}
+
+func TestHTTPReceiver_Status(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []ReceiverOption
+		event   string
+		body    string
+		handler func() error
+		stopped bool
+		want    int
+	}{
+		{name: "handled", event: "MESSAGE_CREATE", body: `{}`, want: http.StatusNoContent},
+		{name: "missing event", body: `{}`, want: http.StatusBadRequest},
+		{name: "invalid event name", event: "a.b.c", body: `{}`, want: http.StatusBadRequest},
+		{name: "malformed payload", event: "MESSAGE_CREATE", body: `{"content":1}`, want: http.StatusBadRequest},
+		{
+			name:    "handler failed",
+			event:   "MESSAGE_CREATE",
+			body:    `{}`,
+			handler: func() error { return errors.New("database is down") },
+			want:    http.StatusInternalServerError,
+		},
+		{
+			name:    "receiver stopped",
+			opts:    []ReceiverOption{WithWorkerPool(WorkerPoolConfig{Workers: 1})},
+			event:   "MESSAGE_CREATE",
+			body:    `{}`,
+			stopped: true,
+			want:    http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewHTTPReceiver(&HTTPReceiverConf{Secret: []byte("secret")}, tt.opts...)
+			_, err := r.On(func(_ context.Context, _ rest.RESTClient, _ *objects.MessageCreate) error {
+				if tt.handler != nil {
+					return tt.handler()
+				}
+				return nil
+			})
+			require.NoError(t, err)
+
+			if tt.stopped {
+				require.NoError(t, runReceiver(t, r)())
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, signedRequest(tt.event, []byte(tt.body)))
+			require.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestHTTPReceiver_QueueFull(t *testing.T) {
+	r := NewHTTPReceiver(&HTTPReceiverConf{Secret: []byte("secret")}, WithWorkerPool(WorkerPoolConfig{
+		Workers:   1,
+		QueueSize: 1,
+		Policy:    QueuePolicyDrop,
+	}))
+
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	_, err := r.On(func(_ context.Context, _ rest.RESTClient, _ *objects.MessageCreate) {
+		started <- struct{}{}
+		<-release
+	})
+	require.NoError(t, err)
+	defer close(release)
+
+	runReceiver(t, r)
+
+	want := []int{http.StatusNoContent, http.StatusNoContent, http.StatusServiceUnavailable}
+	for i, status := range want {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, signedRequest("MESSAGE_CREATE", []byte(`{}`)))
+		require.Equal(t, status, w.Code, "delivery %d", i)
+		if i == 0 {
+			receive(t, started)
+		}
+	}
+}
+
+func TestHTTPReceiver_SignedMetadata(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   int
+	}{
+		{name: "untouched", want: http.StatusNoContent},
+		{name: "event", header: webhook.EventHeader, value: "MESSAGE_UPDATE", want: http.StatusUnauthorized},
+		{name: "delivery", header: webhook.DeliveryHeader, value: "other", want: http.StatusUnauthorized},
+		{name: "shard", header: webhook.ShardHeader, value: "2", want: http.StatusUnauthorized},
+		{name: "sequence", header: webhook.SequenceHeader, value: "8", want: http.StatusUnauthorized},
+		{name: "session", header: webhook.SessionHeader, value: "b", want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewHTTPReceiver(&HTTPReceiverConf{Secret: []byte("secret")})
+			_, err := r.On(func(_ context.Context, _ rest.RESTClient, _ *objects.MessageCreate) {})
+			require.NoError(t, err)
+
+			req := signedRequest("MESSAGE_CREATE", []byte(`{}`),
+				webhook.DeliveryHeader, "delivery",
+				webhook.ShardHeader, "1",
+				webhook.SequenceHeader, "7",
+				webhook.SessionHeader, "a",
+			)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			require.Equal(t, tt.want, w.Code)
+		})
+	}
+}
@@ -17,8 +17,8 @@ func NewLocalReceiver(opts ...ReceiverOption) *LocalReceiver {
 }
 
 func (r *LocalReceiver) Run(ctx context.Context) error {
-	for range ctx.Done() {
-	}
-
-	return nil
+	return r.run(ctx, func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
 }
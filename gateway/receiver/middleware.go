@@ -0,0 +1,144 @@
+package receiver
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"wumpgo.dev/wumpgo/objects"
+)
+
+// NextFunc invokes the next middleware in the chain, or the handler itself
+// for the innermost middleware. payload is the decoded event, e.g.
+// *objects.MessageCreate. The error is the one returned by the handler.
+type NextFunc func(ctx context.Context, event string, payload interface{}) error
+
+// Middleware wraps every handler invocation. A middleware may skip the
+// handler by not calling next.
+type Middleware func(next NextFunc) NextFunc
+
+func (e *eventRouter) chain(next NextFunc) NextFunc {
+	for i := len(e.middleware) - 1; i >= 0; i-- {
+		next = e.middleware[i](next)
+	}
+	return next
+}
+
+// LoggingMiddleware logs every handler invocation and how long it took.
+func LoggingMiddleware(l zerolog.Logger) Middleware {
+	return func(next NextFunc) NextFunc {
+		return func(ctx context.Context, event string, payload interface{}) error {
+			start := time.Now()
+			err := next(ctx, event, payload)
+			l.Debug().Err(err).Str("event", event).Dur("duration", time.Since(start)).Msg("handled event")
+			return err
+		}
+	}
+}
+
+// TimingMiddleware reports how long every handler invocation took and
+// whether it failed, e.g. to record it in a metrics histogram.
+func TimingMiddleware(observe func(event string, took time.Duration, err error)) Middleware {
+	return func(next NextFunc) NextFunc {
+		return func(ctx context.Context, event string, payload interface{}) error {
+			start := time.Now()
+			err := next(ctx, event, payload)
+			observe(event, time.Since(start), err)
+			return err
+		}
+	}
+}
+
+// RecoverMiddleware recovers from panics in handlers before the middleware
+// that comes before it sees them, and reports them to onPanic. The panic is
+// turned into the handler's error, as the receiver does for panics that
+// reach it. onPanic may be nil.
+func RecoverMiddleware(onPanic func(event string, err error)) Middleware {
+	return func(next NextFunc) NextFunc {
+		return func(ctx context.Context, event string, payload interface{}) (err error) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					err = fmt.Errorf("panic: %v", rec)
+					if onPanic != nil {
+						onPanic(event, err)
+					}
+				}
+			}()
+			return next(ctx, event, payload)
+		}
+	}
+}
+
+// AllowGuildsMiddleware only calls handlers for events from the given guilds.
+// Events that don't belong to a guild are not affected.
+func AllowGuildsMiddleware(ids ...objects.Snowflake) Middleware {
+	return guildFilter(ids, true)
+}
+
+// DenyGuildsMiddleware skips handlers for events from the given guilds.
+func DenyGuildsMiddleware(ids ...objects.Snowflake) Middleware {
+	return guildFilter(ids, false)
+}
+
+func guildFilter(ids []objects.Snowflake, allow bool) Middleware {
+	set := make(map[objects.Snowflake]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+
+	return func(next NextFunc) NextFunc {
+		return func(ctx context.Context, event string, payload interface{}) error {
+			if id, ok := guildIDOf(event, payload); ok {
+				if _, found := set[id]; found != allow {
+					return nil
+				}
+			}
+			return next(ctx, event, payload)
+		}
+	}
+}
+
+var snowflakeType = reflect.TypeOf(objects.Snowflake(0))
+
+// guildIDOf finds the guild ID of a decoded event payload.
+func guildIDOf(event string, payload interface{}) (objects.Snowflake, bool) {
+	v := reflect.Indirect(reflect.ValueOf(payload))
+	if v.Kind() != reflect.Struct {
+		return 0, false
+	}
+
+	field := "GuildID"
+	if strings.HasPrefix(event, "guild_") && (strings.HasSuffix(event, "_create") ||
+		strings.HasSuffix(event, "_update") || strings.HasSuffix(event, "_delete")) {
+		if _, ok := v.Type().FieldByName("Guild"); ok {
+			field = "ID"
+		}
+	}
+
+	sf, ok := v.Type().FieldByName(field)
+	if !ok {
+		return 0, false
+	}
+
+	f, err := v.FieldByIndexErr(sf.Index)
+	if err != nil {
+		return 0, false
+	}
+
+	if f.Kind() == reflect.Pointer {
+		if f.IsNil() {
+			return 0, false
+		}
+		f = f.Elem()
+	}
+
+	if f.Type() != snowflakeType {
+		return 0, false
+	}
+
+	id := f.Interface().(objects.Snowflake)
+	return id, id != 0
+}
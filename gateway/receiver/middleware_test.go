@@ -0,0 +1,145 @@
+package receiver
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"wumpgo.dev/wumpgo/objects"
+	"wumpgo.dev/wumpgo/rest"
+)
+
+func TestMiddleware_Order(t *testing.T) {
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next NextFunc) NextFunc {
+			return func(ctx context.Context, event string, payload interface{}) error {
+				calls = append(calls, name+">")
+				err := next(ctx, event, payload)
+				calls = append(calls, "<"+name)
+				return err
+			}
+		}
+	}
+
+	r := NewLocalReceiver(WithMiddleware(trace("a"), trace("b")), WithMiddleware(trace("c")))
+	_, err := r.On(func(_ context.Context, _ rest.RESTClient, _ *objects.MessageCreate) {
+		calls = append(calls, "handler")
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, r.Route("MESSAGE_CREATE", messageCreate(1, "")))
+	require.Equal(t, []string{"a>", "b>", "c>", "handler", "<c", "<b", "<a"}, calls)
+}
+
+func TestMiddleware_Builtin(t *testing.T) {
+	errHandler := errors.New("handler failed")
+
+	tests := []struct {
+		name       string
+		middleware Middleware
+		event      string
+		data       string
+		handler    func() error
+		wantCalled bool
+		wantErr    string
+	}{
+		{
+			name:       "allowed guild",
+			middleware: AllowGuildsMiddleware(1),
+			event:      "MESSAGE_CREATE",
+			data:       `{"guild_id":"1"}`,
+			wantCalled: true,
+		},
+		{
+			name:       "not allowed guild",
+			middleware: AllowGuildsMiddleware(1),
+			event:      "MESSAGE_CREATE",
+			data:       `{"guild_id":"2"}`,
+		},
+		{
+			name:       "allow ignores events without guild",
+			middleware: AllowGuildsMiddleware(1),
+			event:      "MESSAGE_CREATE",
+			data:       `{}`,
+			wantCalled: true,
+		},
+		{
+			name:       "denied guild",
+			middleware: DenyGuildsMiddleware(1),
+			event:      "MESSAGE_CREATE",
+			data:       `{"guild_id":"1"}`,
+		},
+		{
+			name:       "denied guild event",
+			middleware: DenyGuildsMiddleware(1),
+			event:      "GUILD_CREATE",
+			data:       `{"id":"1"}`,
+		},
+		{
+			name:       "recovered panic",
+			middleware: RecoverMiddleware(nil),
+			event:      "MESSAGE_CREATE",
+			data:       `{}`,
+			handler:    func() error { panic("boom") },
+			wantCalled: true,
+			wantErr:    "panic: boom",
+		},
+		{
+			name:       "timed error",
+			middleware: TimingMiddleware(func(string, time.Duration, error) {}),
+			event:      "MESSAGE_CREATE",
+			data:       `{}`,
+			handler:    func() error { return errHandler },
+			wantCalled: true,
+			wantErr:    errHandler.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewLocalReceiver(WithMiddleware(tt.middleware))
+
+			called := false
+			handler := func() error {
+				called = true
+				if tt.handler != nil {
+					return tt.handler()
+				}
+				return nil
+			}
+
+			var err error
+			if strings.HasPrefix(tt.event, "GUILD_") {
+				_, err = r.On(func(_ context.Context, _ rest.RESTClient, _ *objects.GuildCreate) error { return handler() })
+			} else {
+				_, err = r.On(func(_ context.Context, _ rest.RESTClient, _ *objects.MessageCreate) error { return handler() })
+			}
+			require.NoError(t, err)
+
+			err = r.Route(tt.event, []byte(tt.data))
+			require.Equal(t, tt.wantCalled, called)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTimingMiddleware(t *testing.T) {
+	var observed []string
+	r := NewLocalReceiver(WithMiddleware(TimingMiddleware(func(event string, took time.Duration, err error) {
+		observed = append(observed, event)
+	})))
+
+	_, err := r.On(func(_ context.Context, _ rest.RESTClient, _ *objects.MessageCreate) {})
+	require.NoError(t, err)
+
+	require.NoError(t, r.Route("MESSAGE_CREATE", messageCreate(1, "")))
+	require.Equal(t, []string{"message_create"}, observed)
+}
@@ -24,7 +24,19 @@ func NewNATSReceiver(url string, natsOptions []nats.Option, opts ...ReceiverOpti
 	return &NATSReceiver{conn: conn, eventRouter: router}, nil
 }
 
+// NATS headers carrying event metadata, set by dispatcher.NATSDispatcher when
+// the server supports headers.
+const (
+	NATSShardHeader    = "Wumpgo-Shard"
+	NATSSequenceHeader = "Wumpgo-Sequence"
+	NATSSessionHeader  = "Wumpgo-Session"
+)
+
 func (r *NATSReceiver) Run(ctx context.Context) error {
+	return r.run(ctx, r.receive)
+}
+
+func (r *NATSReceiver) receive(ctx context.Context) error {
 	ch := make(chan *nats.Msg, 64)
 	var sub *nats.Subscription
 	var err error
@@ -40,7 +52,9 @@ func (r *NATSReceiver) Run(ctx context.Context) error {
 	for {
 		select {
 		case msg := <-ch:
-			if err := r.Route(msg.Subject, msg.Data); err != nil {
+			meta := ParseMetadata(msg.Header.Get(NATSShardHeader), msg.Header.Get(NATSSequenceHeader))
+			meta.Session = msg.Header.Get(NATSSessionHeader)
+			if err := r.RouteWithMetadata(meta, msg.Subject, msg.Data); err != nil {
 				r.log.Warn().Err(err).Str("event", msg.Subject).Msg("failed to route event")
 			}
 		case <-ctx.Done():
@@ -1,6 +1,8 @@
 package receiver
 
 import (
+	"time"
+
 	"github.com/rs/zerolog"
 	"wumpgo.dev/wumpgo/rest"
 )
@@ -30,3 +32,78 @@ func WithGroupName(name string) ReceiverOption {
 		e.groupName = name
 	}
 }
+
+// WithGRPCEvents limits the events a GRPCReceiver subscribes to, so the
+// dispatcher doesn't send events the receiver has no handlers for. Events
+// outside of names never reach handlers registered later, such as WaitFor's.
+func WithGRPCEvents(names ...string) ReceiverOption {
+	return func(e *eventRouter) {
+		e.grpcEvents = append(e.grpcEvents, names...)
+	}
+}
+
+// WithWorkerPool handles events on a bounded pool of workers instead of the
+// goroutine that routed them. The workers run while Run does, events routed
+// before are queued until Run is called.
+func WithWorkerPool(conf WorkerPoolConfig) ReceiverOption {
+	return func(e *eventRouter) {
+		e.poolConf = &conf
+	}
+}
+
+// WithEventTimeout sets a deadline shared by every handler of an event.
+func WithEventTimeout(d time.Duration) ReceiverOption {
+	return func(e *eventRouter) {
+		e.eventTimeout = d
+	}
+}
+
+// WithHandlerTimeout sets a deadline on the context passed to each handler.
+func WithHandlerTimeout(d time.Duration) ReceiverOption {
+	return func(e *eventRouter) {
+		e.handlerTimeout = d
+	}
+}
+
+// WithMiddleware wraps every handler invocation with the given middleware.
+// Middleware run in the order they are registered, the first one being the
+// outermost.
+func WithMiddleware(m ...Middleware) ReceiverOption {
+	return func(e *eventRouter) {
+		e.middleware = append(e.middleware, m...)
+	}
+}
+
+// WithRetryPolicy retries handlers that return an error.
+func WithRetryPolicy(p RetryPolicy) ReceiverOption {
+	return func(e *eventRouter) {
+		e.retryPolicy = &p
+	}
+}
+
+// WithDeadLetter sets the handler for events whose handler failed after
+// every retry. Without it such failures are logged and returned by Route.
+func WithDeadLetter(h DeadLetterHandler) ReceiverOption {
+	return func(e *eventRouter) {
+		e.deadLetter = h
+	}
+}
+
+// WithIsolatedPayloads decodes events separately for every handler instead
+// of sharing one decoded payload, for handlers that modify their payload.
+func WithIsolatedPayloads() ReceiverOption {
+	return func(e *eventRouter) {
+		e.isolatedPayloads = true
+	}
+}
+
+// WithDeduplication drops events that were already routed, so that handlers
+// with side effects don't run twice when a transport redelivers an event.
+func WithDeduplication(conf DedupConfig) ReceiverOption {
+	return func(e *eventRouter) {
+		if conf.Store == nil {
+			conf.Store = NewMemoryDedupStore()
+		}
+		e.dedup = &conf
+	}
+}
@@ -0,0 +1,138 @@
+package receiver
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"wumpgo.dev/wumpgo/objects"
+)
+
+// payloads decodes the data of a single event on demand.
+type payloads struct {
+	data     json.RawMessage
+	isolated bool
+	decoded  map[reflect.Type]interface{}
+	partial  *Partial
+}
+
+func newPayloads(j job, isolated bool) *payloads {
+	return &payloads{
+		data:     j.data,
+		isolated: isolated,
+		partial:  &Partial{Event: j.meta.Name, data: j.data},
+	}
+}
+
+func (p *payloads) get(h EventHandlerIface) (interface{}, error) {
+	payload := h.New()
+
+	// Raw handlers share the event data, there is nothing to decode.
+	if raw, ok := payload.(*json.RawMessage); ok {
+		*raw = p.data
+		return raw, nil
+	}
+
+	if p.isolated {
+		return payload, json.Unmarshal(p.data, payload)
+	}
+
+	t := reflect.TypeOf(payload)
+	if v, ok := p.decoded[t]; ok {
+		return v, nil
+	}
+
+	if err := json.Unmarshal(p.data, payload); err != nil {
+		return nil, err
+	}
+
+	if p.decoded == nil {
+		p.decoded = make(map[reflect.Type]interface{}, 1)
+	}
+	p.decoded[t] = payload
+
+	return payload, nil
+}
+
+// Partial gives filters access to individual top level fields of an event
+// without decoding the full payload. Fields are decoded on first use and
+// shared between the filters of an event.
+type Partial struct {
+	// Event is the gateway event name, e.g. "MESSAGE_CREATE".
+	Event string
+
+	data   json.RawMessage
+	once   sync.Once
+	fields map[string]json.RawMessage
+	err    error
+}
+
+// Raw returns the undecoded event data. It must not be modified.
+func (p *Partial) Raw() json.RawMessage {
+	return p.data
+}
+
+// Field decodes the top level field name into v. It returns false if the
+// field is missing or null.
+func (p *Partial) Field(name string, v interface{}) (bool, error) {
+	p.once.Do(func() {
+		p.err = json.Unmarshal(p.data, &p.fields)
+	})
+	if p.err != nil {
+		return false, p.err
+	}
+
+	raw, ok := p.fields[name]
+	if !ok || string(raw) == "null" {
+		return false, nil
+	}
+
+	if err := json.Unmarshal(raw, v); err != nil {
+		return false, fmt.Errorf("failed to decode %s.%s: %w", p.Event, name, err)
+	}
+
+	return true, nil
+}
+
+// GuildID returns the guild the event belongs to. For GUILD_CREATE,
+// GUILD_UPDATE and GUILD_DELETE this is the guild's own ID.
+func (p *Partial) GuildID() (objects.Snowflake, bool) {
+	field := "guild_id"
+	switch p.Event {
+	case "GUILD_CREATE", "GUILD_UPDATE", "GUILD_DELETE":
+		field = "id"
+	}
+	return p.snowflake(field)
+}
+
+// ChannelID returns the channel the event belongs to, if any.
+func (p *Partial) ChannelID() (objects.Snowflake, bool) {
+	return p.snowflake("channel_id")
+}
+
+func (p *Partial) snowflake(field string) (objects.Snowflake, bool) {
+	var id objects.Snowflake
+	ok, err := p.Field(field, &id)
+	if err != nil || !ok {
+		return 0, false
+	}
+	return id, id != 0
+}
+
+type filteredHandler struct {
+	filter  func(*Partial) bool
+	handler HandlerFunc
+}
+
+// Filtered wraps a handler so that it is only called for events filter
+// accepts. The filter runs before the event is decoded, so events no handler
+// is interested in are never fully decoded.
+//
+//	r.On(receiver.Filtered(func(p *receiver.Partial) bool {
+//		id, _ := p.GuildID()
+//		return id == myGuild
+//	}, onMessageCreate))
+func Filtered(filter func(*Partial) bool, handler HandlerFunc) HandlerFunc {
+	return filteredHandler{filter: filter, handler: handler}
+}
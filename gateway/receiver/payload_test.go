@@ -0,0 +1,100 @@
+package receiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"wumpgo.dev/wumpgo/objects"
+	"wumpgo.dev/wumpgo/rest"
+)
+
+func TestPayloads_Shared(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []ReceiverOption
+		wantSame bool
+	}{
+		{name: "shared", wantSame: true},
+		{name: "isolated", opts: []ReceiverOption{WithIsolatedPayloads()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewLocalReceiver(tt.opts...)
+
+			var got []*objects.MessageCreate
+			for i := 0; i < 2; i++ {
+				_, err := r.On(func(_ context.Context, _ rest.RESTClient, m *objects.MessageCreate) {
+					got = append(got, m)
+				})
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, r.Route("MESSAGE_CREATE", messageCreate(1, "hi")))
+			require.Len(t, got, 2)
+			require.Equal(t, "hi", got[1].Content)
+			require.Equal(t, tt.wantSame, got[0] == got[1])
+		})
+	}
+}
+
+func TestFiltered(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		want  bool
+		event string
+	}{
+		{name: "matching guild", event: "MESSAGE_CREATE", data: `{"guild_id":"2","channel_id":"5"}`, want: true},
+		{name: "other guild", event: "MESSAGE_CREATE", data: `{"guild_id":"3","channel_id":"5"}`},
+		{name: "other channel", event: "MESSAGE_CREATE", data: `{"guild_id":"2","channel_id":"6"}`},
+		{name: "no guild", event: "MESSAGE_CREATE", data: `{"channel_id":"5"}`},
+		// Rejected events are never decoded, so a malformed payload is fine.
+		{name: "rejected malformed", event: "MESSAGE_CREATE", data: `{"guild_id":"3","content":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewLocalReceiver()
+
+			called := false
+			_, err := r.On(Filtered(func(p *Partial) bool {
+				id, _ := p.GuildID()
+				return id == 2
+			}, Filtered(func(p *Partial) bool {
+				id, _ := p.ChannelID()
+				return id == 5
+			}, func(_ context.Context, _ rest.RESTClient, _ *objects.MessageCreate) {
+				called = true
+			})))
+			require.NoError(t, err)
+
+			require.NoError(t, r.Route(tt.event, []byte(tt.data)))
+			require.Equal(t, tt.want, called)
+		})
+	}
+}
+
+func TestPartial_Field(t *testing.T) {
+	p := &Partial{Event: "GUILD_CREATE", data: []byte(`{"id":"7","name":"wumpus","icon":null}`)}
+
+	id, ok := p.GuildID()
+	require.True(t, ok)
+	require.Equal(t, objects.Snowflake(7), id)
+
+	var name string
+	ok, err := p.Field("name", &name)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "wumpus", name)
+
+	var icon string
+	ok, err = p.Field("icon", &icon)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	var bad int
+	_, err = p.Field("name", &bad)
+	require.Error(t, err)
+}
@@ -0,0 +1,226 @@
+package receiver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/maphash"
+	"runtime"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	"wumpgo.dev/wumpgo/gateway/internal/eventdata"
+)
+
+var (
+	// ErrQueueFull is returned by Route when the worker pool queue is full
+	// and the pool is configured with QueuePolicyDrop.
+	ErrQueueFull = errors.New("event queue is full")
+	// ErrReceiverStopped is returned by Route once the receiver's context is
+	// done and events are no longer handled.
+	ErrReceiverStopped = errors.New("receiver is stopped")
+	// ErrDrainTimeout is returned by Run when events were still queued once
+	// the worker pool's DrainTimeout ran out.
+	ErrDrainTimeout = errors.New("worker pool did not drain in time")
+)
+
+// QueuePolicy decides what happens to an event when the worker pool queue
+// is full.
+type QueuePolicy int
+
+const (
+	// QueuePolicyBlock makes Route wait until there is room in the queue,
+	// pushing back on the transport.
+	QueuePolicyBlock QueuePolicy = iota
+	// QueuePolicyDrop discards the event and makes Route return ErrQueueFull.
+	QueuePolicyDrop
+)
+
+// OrderKeyFunc returns the ordering key of an event. Events with the same key
+// are handled one at a time, in the order they were routed. Events for which
+// ok is false are not ordered.
+type OrderKeyFunc func(event string, data json.RawMessage) (key string, ok bool)
+
+// GuildOrderKey orders events per guild.
+func GuildOrderKey(event string, data json.RawMessage) (string, bool) {
+	id, ok := eventdata.GuildID(event, data)
+	if !ok {
+		return "", false
+	}
+	return id.String(), true
+}
+
+type WorkerPoolConfig struct {
+	// Workers is the maximum number of events handled concurrently.
+	// Defaults to runtime.NumCPU().
+	Workers int
+	// QueueSize is the number of events waiting for a worker before Policy
+	// applies. Defaults to 1024.
+	QueueSize int
+	// Policy applies when the queue is full.
+	Policy QueuePolicy
+	// OrderKey, if set, handles events sharing a key in order.
+	OrderKey OrderKeyFunc
+	// DrainTimeout is how long Run keeps handling queued events once its
+	// context is done. Defaults to 10 seconds.
+	DrainTimeout time.Duration
+}
+
+type job struct {
+	event    string
+	meta     EventMetadata
+	handlers []*Registration
+	data     json.RawMessage
+	// dedupKey is released if the job fails.
+	dedupKey string
+}
+
+type workerPool struct {
+	queues       []chan job
+	workers      int
+	policy       QueuePolicy
+	orderKey     OrderKeyFunc
+	drainTimeout time.Duration
+	handle       func(job)
+	seed         maphash.Seed
+	next         *atomic.Uint64
+	started      *atomic.Bool
+	running      sync.WaitGroup
+
+	// lock is held for reading while submitting, so that the queues aren't
+	// closed under a sender.
+	lock    sync.RWMutex
+	stopped bool
+}
+
+func newWorkerPool(conf WorkerPoolConfig, handle func(job)) *workerPool {
+	workers := conf.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	queueSize := conf.QueueSize
+	if queueSize <= 0 {
+		queueSize = 1024
+	}
+
+	drainTimeout := conf.DrainTimeout
+	if drainTimeout <= 0 {
+		drainTimeout = 10 * time.Second
+	}
+
+	p := &workerPool{
+		workers:      workers,
+		policy:       conf.Policy,
+		orderKey:     conf.OrderKey,
+		drainTimeout: drainTimeout,
+		handle:       handle,
+		seed:         maphash.MakeSeed(),
+		next:         atomic.NewUint64(0),
+		started:      atomic.NewBool(false),
+	}
+
+	if conf.OrderKey == nil {
+		// Every worker pulls from a single shared queue.
+		p.queues = []chan job{make(chan job, queueSize)}
+		return p
+	}
+
+	// Each worker owns a queue so that events sharing a key are handled
+	// by the same worker.
+	perWorker := queueSize / workers
+	if perWorker < 1 {
+		perWorker = 1
+	}
+	p.queues = make([]chan job, workers)
+	for i := range p.queues {
+		p.queues[i] = make(chan job, perWorker)
+	}
+
+	return p
+}
+
+// start starts the workers. Events submitted before are queued until then.
+func (p *workerPool) start() {
+	for i := 0; i < p.workers; i++ {
+		q := p.queues[i%len(p.queues)]
+		p.running.Add(1)
+		go func() {
+			defer p.running.Done()
+			p.work(q)
+		}()
+	}
+}
+
+// stop closes the queues and waits for the workers to handle the events
+// left in them, until ctx is done.
+func (p *workerPool) stop(ctx context.Context) error {
+	p.lock.Lock()
+	if !p.stopped {
+		p.stopped = true
+		for _, q := range p.queues {
+			close(q)
+		}
+	}
+	p.lock.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		pending := 0
+		for _, q := range p.queues {
+			pending += len(q)
+		}
+		return fmt.Errorf("%w: %d events were not handled", ErrDrainTimeout, pending)
+	}
+}
+
+func (p *workerPool) work(q chan job) {
+	for j := range q {
+		p.handle(j)
+	}
+}
+
+func (p *workerPool) queueFor(j job) chan job {
+	if len(p.queues) == 1 {
+		return p.queues[0]
+	}
+
+	if key, ok := p.orderKey(j.event, j.data); ok {
+		return p.queues[maphash.String(p.seed, key)%uint64(len(p.queues))]
+	}
+
+	return p.queues[p.next.Inc()%uint64(len(p.queues))]
+}
+
+func (p *workerPool) submit(j job) error {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if p.stopped {
+		return ErrReceiverStopped
+	}
+
+	q := p.queueFor(j)
+
+	if p.policy == QueuePolicyDrop {
+		select {
+		case q <- j:
+			return nil
+		default:
+			return ErrQueueFull
+		}
+	}
+
+	q <- j
+	return nil
+}
@@ -0,0 +1,217 @@
+package receiver
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"wumpgo.dev/wumpgo/objects"
+	"wumpgo.dev/wumpgo/rest"
+)
+
+func TestWorkerPool_OrderKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		workers int
+	}{
+		{name: "single worker", workers: 1},
+		{name: "more keys than workers", workers: 2},
+		{name: "more workers than keys", workers: 8},
+	}
+
+	const guilds, perGuild = 4, 50
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewLocalReceiver(WithWorkerPool(WorkerPoolConfig{
+				Workers:  tt.workers,
+				OrderKey: GuildOrderKey,
+			}))
+
+			var (
+				lock sync.Mutex
+				seen = make(map[objects.Snowflake][]int)
+				wg   sync.WaitGroup
+			)
+			wg.Add(guilds * perGuild)
+
+			_, err := r.On(func(_ context.Context, _ rest.RESTClient, m *objects.MessageCreate) {
+				defer wg.Done()
+				n, _ := strconv.Atoi(m.Content)
+				if n%7 == 0 {
+					time.Sleep(time.Millisecond)
+				}
+				lock.Lock()
+				seen[m.GuildID] = append(seen[m.GuildID], n)
+				lock.Unlock()
+			})
+			require.NoError(t, err)
+
+			runReceiver(t, r)
+
+			for i := 0; i < perGuild; i++ {
+				for g := 1; g <= guilds; g++ {
+					require.NoError(t, r.Route("MESSAGE_CREATE", messageCreate(g, strconv.Itoa(i))))
+				}
+			}
+			wg.Wait()
+
+			for g := 1; g <= guilds; g++ {
+				got := seen[objects.Snowflake(g)]
+				require.Len(t, got, perGuild)
+				for i, n := range got {
+					require.Equal(t, i, n, "guild %d", g)
+				}
+			}
+		})
+	}
+}
+
+func TestWorkerPool_QueuePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  QueuePolicy
+		wantErr error
+		blocks  bool
+	}{
+		{name: "drop", policy: QueuePolicyDrop, wantErr: ErrQueueFull},
+		{name: "block", policy: QueuePolicyBlock, blocks: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewLocalReceiver(WithWorkerPool(WorkerPoolConfig{
+				Workers:   1,
+				QueueSize: 1,
+				Policy:    tt.policy,
+			}))
+
+			started := make(chan struct{}, 3)
+			release := make(chan struct{})
+			_, err := r.On(func(_ context.Context, _ rest.RESTClient, _ *objects.MessageCreate) {
+				started <- struct{}{}
+				<-release
+			})
+			require.NoError(t, err)
+
+			runReceiver(t, r)
+
+			// The first event occupies the worker, the second fills the queue.
+			require.NoError(t, r.Route("MESSAGE_CREATE", messageCreate(1, "1")))
+			receive(t, started)
+			require.NoError(t, r.Route("MESSAGE_CREATE", messageCreate(1, "2")))
+
+			routed := make(chan error, 1)
+			go func() { routed <- r.Route("MESSAGE_CREATE", messageCreate(1, "3")) }()
+
+			if !tt.blocks {
+				require.ErrorIs(t, receive(t, routed), tt.wantErr)
+				close(release)
+				return
+			}
+
+			select {
+			case err := <-routed:
+				t.Fatalf("Route returned %v while the queue was full", err)
+			case <-time.After(50 * time.Millisecond):
+			}
+
+			close(release)
+			require.NoError(t, receive(t, routed))
+		})
+	}
+}
+
+func TestWorkerPool_Timeouts(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []ReceiverOption
+		want time.Duration
+	}{
+		{name: "handler", opts: []ReceiverOption{WithHandlerTimeout(20 * time.Millisecond)}, want: 20 * time.Millisecond},
+		{name: "event", opts: []ReceiverOption{WithEventTimeout(30 * time.Millisecond)}, want: 30 * time.Millisecond},
+		{
+			name: "handler within event",
+			opts: []ReceiverOption{WithHandlerTimeout(time.Second), WithEventTimeout(20 * time.Millisecond)},
+			want: 20 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]ReceiverOption{WithWorkerPool(WorkerPoolConfig{Workers: 1})}, tt.opts...)
+			r := NewLocalReceiver(opts...)
+
+			type result struct {
+				err  error
+				took time.Duration
+			}
+			results := make(chan result, 1)
+			_, err := r.On(func(ctx context.Context, _ rest.RESTClient, _ *objects.MessageCreate) {
+				start := time.Now()
+				<-ctx.Done()
+				results <- result{err: ctx.Err(), took: time.Since(start)}
+			})
+			require.NoError(t, err)
+
+			runReceiver(t, r)
+			require.NoError(t, r.Route("MESSAGE_CREATE", messageCreate(1, "")))
+
+			res := receive(t, results)
+			require.ErrorIs(t, res.err, context.DeadlineExceeded)
+			require.Less(t, res.took, tt.want+500*time.Millisecond)
+		})
+	}
+}
+
+func TestWorkerPool_Drain(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		block   bool
+		wantErr error
+	}{
+		{name: "drained", timeout: time.Second},
+		{name: "timed out", timeout: 50 * time.Millisecond, block: true, wantErr: ErrDrainTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewLocalReceiver(WithWorkerPool(WorkerPoolConfig{
+				Workers:      1,
+				DrainTimeout: tt.timeout,
+			}))
+
+			var (
+				lock    sync.Mutex
+				handled int
+			)
+			_, err := r.On(func(ctx context.Context, _ rest.RESTClient, _ *objects.MessageCreate) {
+				if tt.block {
+					<-ctx.Done()
+					return
+				}
+				lock.Lock()
+				handled++
+				lock.Unlock()
+			})
+			require.NoError(t, err)
+
+			// Events routed before Run are queued until it starts.
+			for i := 0; i < 5; i++ {
+				require.NoError(t, r.Route("MESSAGE_CREATE", messageCreate(1, "")))
+			}
+
+			stop := runReceiver(t, r)
+			require.ErrorIs(t, stop(), tt.wantErr)
+
+			if !tt.block {
+				require.Equal(t, 5, handled)
+			}
+			require.ErrorIs(t, r.Route("MESSAGE_CREATE", messageCreate(1, "")), ErrReceiverStopped)
+		})
+	}
+}
@@ -4,35 +4,82 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"runtime/debug"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/DataDog/gostackparse"
 	"github.com/rs/zerolog"
 	"wumpgo.dev/wumpgo/rest"
 )
 
+// HandlerFunc is an event handler, e.g.
+// func(context.Context, rest.RESTClient, *objects.MessageCreate).
+//
+// Events are decoded once and the payload is shared between every handler of
+// the event. Handlers must treat payloads, and raw event data, as read only
+// and copy them before making changes, unless the receiver is configured
+// WithIsolatedPayloads.
 type HandlerFunc interface{}
 
 // Receiver is a generic interface for receiving events from a Dispatcher
 type Receiver interface {
-	On(handler HandlerFunc) error
+	EventHandlers
+	On(handler HandlerFunc) (*Registration, error)
+	Once(handler HandlerFunc) (*Registration, error)
+	OnRaw(pattern string, handler HandlerFunc) (*Registration, error)
+	Off(reg *Registration)
 	Route(event string, data json.RawMessage) error
 	Run(ctx context.Context) error
 }
 
+// MalformedEventError is returned by Route for events that can't be routed
+// or decoded. Delivering them again won't help.
+type MalformedEventError struct {
+	Event string
+	Err   error
+}
+
+func (e *MalformedEventError) Error() string {
+	return fmt.Sprintf("malformed %s event: %v", e.Event, e.Err)
+}
+
+func (e *MalformedEventError) Unwrap() error {
+	return e.Err
+}
+
 type eventRouter struct {
-	handlers   map[string][]EventHandlerIface
+	handlers     map[string][]*Registration
+	wildcards    []*Registration
+	handlersLock sync.RWMutex
+
 	log        zerolog.Logger
 	client     rest.RESTClient
 	errHandler func(error)
 	groupName  string
+	grpcEvents []string
+
+	poolConf       *WorkerPoolConfig
+	pool           *workerPool
+	handlerTimeout time.Duration
+	eventTimeout   time.Duration
+	middleware     []Middleware
+	retryPolicy    *RetryPolicy
+	deadLetter     DeadLetterHandler
+
+	isolatedPayloads bool
+	dedup            *DedupConfig
+
+	ctxLock sync.RWMutex
+	runCtx  context.Context
 }
 
 func newEventRouter(opts ...ReceiverOption) *eventRouter {
 	router := &eventRouter{
-		handlers: make(map[string][]EventHandlerIface),
+		handlers: make(map[string][]*Registration),
 		log:      zerolog.Nop(),
 	}
 
@@ -40,65 +87,178 @@ func newEventRouter(opts ...ReceiverOption) *eventRouter {
 		o(router)
 	}
 
+	if router.poolConf != nil {
+		router.pool = newWorkerPool(*router.poolConf, router.handle)
+	}
+
 	return router
 }
 
-func (e *eventRouter) On(handler HandlerFunc) error {
-	h, evt, err := eventHandlerToEvent(handler)
-	if err != nil {
-		return err
+// run calls receive with ctx and the worker pool running. Once receive
+// returns, events still queued are handled until the pool's DrainTimeout
+// runs out.
+func (e *eventRouter) run(ctx context.Context, receive func(ctx context.Context) error) error {
+	if e.pool == nil {
+		e.bindContext(ctx)
+		return receive(ctx)
 	}
-	e.log.Debug().Str("event", evt).Msg("registered handler for event")
-	e.handlers[evt] = append(e.handlers[evt], h)
-	return nil
-}
 
-func (e *eventRouter) Route(event string, data json.RawMessage) error {
-	defer func() {
-		if rec := recover(); rec != nil {
-			if e.errHandler != nil {
-				e.errHandler(fmt.Errorf("%v", rec))
-			}
+	if !e.pool.started.CompareAndSwap(false, true) {
+		return errors.New("receiver is already running")
+	}
 
-			routines, err := gostackparse.Parse(bytes.NewReader(debug.Stack()))
-			if len(err) > 0 {
-				e.log.Warn().Interface("error", rec).Msg("")
-			} else {
-				e.log.Warn().
-					Interface("error", rec).
-					Str("file", routines[0].Stack[3].File).
-					Int("line", routines[0].Stack[3].Line).
-					Msg("")
-			}
+	// Handlers keep ctx's values, but are only cancelled once the queue
+	// had DrainTimeout to drain after ctx is done.
+	handlerCtx, cancel := context.WithCancel(detach(ctx))
+	defer cancel()
+	e.bindContext(handlerCtx)
 
+	e.pool.start()
+	err := receive(ctx)
+
+	timer := time.AfterFunc(e.pool.drainTimeout, cancel)
+	defer timer.Stop()
+
+	if drainErr := e.pool.stop(handlerCtx); drainErr != nil {
+		e.log.Warn().Err(drainErr).Msg("stopped before every queued event was handled")
+		if err == nil {
+			err = drainErr
 		}
-	}()
+	}
+
+	return err
+}
 
+// Route decodes an event and calls its handlers. If a worker pool is
+// configured the event is queued and handled asynchronously.
+func (e *eventRouter) Route(event string, data json.RawMessage) error {
+	return e.RouteWithMetadata(UnknownMetadata(), event, data)
+}
+
+// RouteWithMetadata is Route for transports that carry event metadata.
+func (e *eventRouter) RouteWithMetadata(meta EventMetadata, event string, data json.RawMessage) error {
 	channelParts := strings.Split(strings.ToLower(event), ".")
 	if len(channelParts) == 1 {
 		event = channelParts[0]
 	} else if len(channelParts) == 2 {
 		event = channelParts[1]
 	} else {
-		return fmt.Errorf("invalid event name %s", event)
+		return &MalformedEventError{Event: event, Err: errors.New("invalid event name")}
 	}
 
-	handlers, ok := e.handlers[event]
-	if !ok {
+	handlers := e.handlersFor(event)
+	if len(handlers) == 0 {
 		e.log.Debug().Msgf("received event %s, but no handlers are declared", event)
 		return nil
 	}
 
-	for _, h := range handlers {
-		payload := h.New()
+	meta.Name = strings.ToUpper(event)
+	if meta.ReceivedAt.IsZero() {
+		meta.ReceivedAt = time.Now()
+	}
+
+	key, duplicate := e.claim(meta, data)
+	if duplicate {
+		return nil
+	}
+
+	j := job{event: event, meta: meta, handlers: handlers, data: data, dedupKey: key}
 
-		err := json.Unmarshal(data, payload)
+	var err error
+	if e.pool != nil {
+		err = e.pool.submit(j)
+	} else {
+		err = e.handleJob(j)
+	}
+
+	if err != nil {
+		e.release(key)
+	}
+
+	return err
+}
+
+// handle runs a job from the worker pool, where there is no caller to
+// return an error to.
+func (e *eventRouter) handle(j job) {
+	if err := e.handleJob(j); err != nil {
+		e.release(j.dedupKey)
+		if errors.Is(err, ErrReceiverStopped) {
+			// Already reported by Run as part of ErrDrainTimeout.
+			return
+		}
+		e.log.Warn().Err(err).Str("event", j.event).Msg("failed to handle event")
+		if e.errHandler != nil {
+			e.errHandler(err)
+		}
+	}
+}
+
+func (e *eventRouter) handleJob(j job) error {
+	ctx, cancel := e.eventContext(j.meta)
+	defer cancel()
+
+	if ctx.Err() != nil {
+		zerolog.Ctx(ctx).Debug().Msg("receiver stopped, skipping event")
+		return ErrReceiverStopped
+	}
+
+	p := newPayloads(j, e.isolatedPayloads)
+
+	var handlerErr error
+	for _, reg := range j.handlers {
+		if !reg.claim(p.partial) {
+			continue
+		}
+
+		payload, err := p.get(reg.handler)
 		if err != nil {
-			return err
+			return &MalformedEventError{Event: j.meta.Name, Err: err}
+		}
+
+		if err := e.invokeWithRetry(ctx, j, reg.handler, payload); err != nil && handlerErr == nil {
+			handlerErr = fmt.Errorf("handler for %s failed: %w", j.meta.Name, err)
 		}
-		ctx := context.Background()
-		h.Handle(ctx, e.client, payload)
 	}
 
-	return nil
+	return handlerErr
+}
+
+// invoke calls a single handler. A panic is turned into the handler's error,
+// so the remaining handlers still run and the retry policy and dead letter
+// handler apply to it like to any other failure.
+func (e *eventRouter) invoke(ctx context.Context, event string, h EventHandlerIface, payload interface{}) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("panic: %v", rec)
+
+			routines, perr := gostackparse.Parse(bytes.NewReader(debug.Stack()))
+			if len(perr) > 0 {
+				e.log.Warn().Interface("error", rec).Msg("handler panicked")
+			} else {
+				e.log.Warn().
+					Interface("error", rec).
+					Str("file", routines[0].Stack[3].File).
+					Int("line", routines[0].Stack[3].Line).
+					Msg("handler panicked")
+			}
+		}
+	}()
+
+	if e.handlerTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.handlerTimeout)
+		defer cancel()
+	}
+
+	next := e.chain(func(ctx context.Context, _ string, payload interface{}) error {
+		return h.Handle(ctx, e.client, payload)
+	})
+	err = next(ctx, event, payload)
+
+	if ctx.Err() == context.DeadlineExceeded {
+		zerolog.Ctx(ctx).Warn().Msg("handler exceeded its deadline")
+	}
+
+	return err
 }
@@ -0,0 +1,65 @@
+package receiver
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// runReceiver runs r until the test ends or the returned stop func is
+// called, which returns Run's error.
+func runReceiver(t *testing.T, r Receiver) (stop func() error) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() { errs <- r.Run(ctx) }()
+
+	var err error
+	stopped := false
+	stop = func() error {
+		if !stopped {
+			stopped = true
+			cancel()
+			select {
+			case err = <-errs:
+			case <-time.After(5 * time.Second):
+				t.Fatal("receiver did not stop")
+			}
+		}
+		return err
+	}
+	t.Cleanup(func() { _ = stop() })
+
+	return stop
+}
+
+func messageCreate(guild int, content string) []byte {
+	return []byte(fmt.Sprintf(`{"guild_id":"%d","content":%q}`, guild, content))
+}
+
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+
+	var zero T
+	return zero
+}
+
+func TestReceiver_RunTwice(t *testing.T) {
+	r := NewLocalReceiver(WithWorkerPool(WorkerPoolConfig{Workers: 1}))
+	runReceiver(t, r)
+
+	require.Eventually(t, func() bool {
+		return r.Run(context.Background()) != nil
+	}, time.Second, 10*time.Millisecond)
+}
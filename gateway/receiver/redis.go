@@ -35,6 +35,10 @@ func NewRedisReceiver(connectOpts *redis.Options, opts ...ReceiverOption) (*Redi
 }
 
 func (r *RedisReceiver) Run(ctx context.Context) error {
+	return r.run(ctx, r.receive)
+}
+
+func (r *RedisReceiver) receive(ctx context.Context) error {
 	r.log.Debug().Msg("starting receive")
 	pubsub := r.conn.PSubscribe(ctx, "discord.*")
 	ch := pubsub.Channel()
@@ -0,0 +1,111 @@
+package receiver
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// RetryPolicy controls how failed handlers are retried. Only handlers that
+// return an error are retried, other handlers of the same event are not
+// called again.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+	// InitialInterval is the delay before the first retry. Defaults to 100ms.
+	InitialInterval time.Duration
+	// MaxInterval caps the delay between retries, which doubles after each
+	// attempt. Defaults to 10s.
+	MaxInterval time.Duration
+	// Retryable reports whether an error is worth retrying. If nil, every
+	// error is retried.
+	Retryable func(err error) bool
+}
+
+func (p *RetryPolicy) delay(attempt int) time.Duration {
+	d := p.InitialInterval
+	if d <= 0 {
+		d = 100 * time.Millisecond
+	}
+
+	max := p.MaxInterval
+	if max <= 0 {
+		max = 10 * time.Second
+	}
+
+	for i := 1; i < attempt && d < max; i++ {
+		d *= 2
+	}
+
+	if d > max {
+		d = max
+	}
+
+	return d
+}
+
+func (p *RetryPolicy) shouldRetry(attempt int, err error) bool {
+	if p == nil || attempt > p.MaxRetries {
+		return false
+	}
+	return p.Retryable == nil || p.Retryable(err)
+}
+
+// DeadLetter is an event whose handler kept failing.
+type DeadLetter struct {
+	Event    string
+	Metadata EventMetadata
+	Data     json.RawMessage
+	Err      error
+	Attempts int
+}
+
+// DeadLetterHandler parks events whose handler failed after every retry, for
+// example by storing them for later inspection or replay.
+type DeadLetterHandler func(ctx context.Context, dl *DeadLetter)
+
+func (e *eventRouter) invokeWithRetry(ctx context.Context, j job, h EventHandlerIface, payload interface{}) error {
+	attempt := 1
+	err := e.invoke(ctx, j.event, h, payload)
+
+	for err != nil && e.retryPolicy.shouldRetry(attempt, err) {
+		select {
+		case <-time.After(e.retryPolicy.delay(attempt)):
+		case <-ctx.Done():
+			return e.failed(ctx, j, err, attempt)
+		}
+
+		attempt++
+		err = e.invoke(ctx, j.event, h, payload)
+	}
+
+	if err != nil {
+		return e.failed(ctx, j, err, attempt)
+	}
+
+	return nil
+}
+
+// failed hands an event over to the dead letter handler. The error is
+// returned to the caller if there is none.
+func (e *eventRouter) failed(ctx context.Context, j job, err error, attempts int) error {
+	l := zerolog.Ctx(ctx)
+
+	if e.deadLetter == nil {
+		l.Warn().Err(err).Int("attempts", attempts).Msg("handler failed")
+		return err
+	}
+
+	l.Warn().Err(err).Int("attempts", attempts).Msg("handler failed, sending event to dead letter handler")
+	e.deadLetter(ctx, &DeadLetter{
+		Event:    j.meta.Name,
+		Metadata: j.meta,
+		Data:     j.data,
+		Err:      err,
+		Attempts: attempts,
+	})
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package receiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"wumpgo.dev/wumpgo/objects"
+	"wumpgo.dev/wumpgo/rest"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	errTransient := errors.New("transient")
+	errFatal := errors.New("fatal")
+
+	tests := []struct {
+		name         string
+		policy       *RetryPolicy
+		deadLetter   bool
+		failures     []error
+		wantAttempts int
+		wantErr      error
+		wantDead     bool
+	}{
+		{
+			name:         "no policy",
+			failures:     []error{errTransient},
+			wantAttempts: 1,
+			wantErr:      errTransient,
+		},
+		{
+			name:         "succeeds after retry",
+			policy:       &RetryPolicy{MaxRetries: 2, InitialInterval: time.Millisecond},
+			failures:     []error{errTransient, errTransient},
+			wantAttempts: 3,
+		},
+		{
+			name:         "gives up",
+			policy:       &RetryPolicy{MaxRetries: 1, InitialInterval: time.Millisecond},
+			failures:     []error{errTransient, errTransient, errTransient},
+			wantAttempts: 2,
+			wantErr:      errTransient,
+		},
+		{
+			name: "not retryable",
+			policy: &RetryPolicy{MaxRetries: 3, InitialInterval: time.Millisecond, Retryable: func(err error) bool {
+				return !errors.Is(err, errFatal)
+			}},
+			failures:     []error{errFatal},
+			wantAttempts: 1,
+			wantErr:      errFatal,
+		},
+		{
+			name:         "dead letter",
+			policy:       &RetryPolicy{MaxRetries: 1, InitialInterval: time.Millisecond},
+			deadLetter:   true,
+			failures:     []error{errTransient, errTransient},
+			wantAttempts: 2,
+			wantDead:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []ReceiverOption
+			if tt.policy != nil {
+				opts = append(opts, WithRetryPolicy(*tt.policy))
+			}
+
+			var dead *DeadLetter
+			if tt.deadLetter {
+				opts = append(opts, WithDeadLetter(func(_ context.Context, dl *DeadLetter) {
+					dead = dl
+				}))
+			}
+
+			r := NewLocalReceiver(opts...)
+
+			attempts := 0
+			_, err := r.On(func(_ context.Context, _ rest.RESTClient, _ *objects.MessageCreate) error {
+				attempts++
+				if attempts <= len(tt.failures) {
+					return tt.failures[attempts-1]
+				}
+				return nil
+			})
+			require.NoError(t, err)
+
+			// Handlers that don't return errors are never retried.
+			plain := 0
+			_, err = r.On(func(_ context.Context, _ rest.RESTClient, _ *objects.MessageCreate) {
+				plain++
+			})
+			require.NoError(t, err)
+
+			err = r.RouteWithMetadata(EventMetadata{Shard: 1, Sequence: 9}, "MESSAGE_CREATE", messageCreate(1, ""))
+			require.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantAttempts, attempts)
+			require.Equal(t, 1, plain)
+
+			if !tt.wantDead {
+				require.Nil(t, dead)
+				return
+			}
+			require.NotNil(t, dead)
+			require.Equal(t, "MESSAGE_CREATE", dead.Event)
+			require.Equal(t, uint64(9), dead.Metadata.Sequence)
+			require.Equal(t, tt.wantAttempts, dead.Attempts)
+			require.ErrorIs(t, dead.Err, errTransient)
+			require.JSONEq(t, string(messageCreate(1, "")), string(dead.Data))
+		})
+	}
+}
+
+func TestRetryPolicy_Panic(t *testing.T) {
+	for _, deadLetter := range []bool{false, true} {
+		t.Run(fmt.Sprintf("dead letter %t", deadLetter), func(t *testing.T) {
+			opts := []ReceiverOption{
+				WithRetryPolicy(RetryPolicy{MaxRetries: 1, InitialInterval: time.Millisecond}),
+				WithDeduplication(DedupConfig{}),
+			}
+
+			var dead *DeadLetter
+			if deadLetter {
+				opts = append(opts, WithDeadLetter(func(_ context.Context, dl *DeadLetter) {
+					dead = dl
+				}))
+			}
+
+			r := NewLocalReceiver(opts...)
+
+			attempts := 0
+			_, err := r.On(func(_ context.Context, _ rest.RESTClient, _ *objects.MessageCreate) error {
+				attempts++
+				panic("boom")
+			})
+			require.NoError(t, err)
+
+			// A panic only fails the handler that panicked.
+			plain := 0
+			_, err = r.On(func(_ context.Context, _ rest.RESTClient, _ *objects.MessageCreate) {
+				plain++
+			})
+			require.NoError(t, err)
+
+			meta := EventMetadata{Shard: 1, Session: "a", Sequence: 9}
+			err = r.RouteWithMetadata(meta, "MESSAGE_CREATE", messageCreate(1, ""))
+			require.Equal(t, 2, attempts)
+			require.Equal(t, 1, plain)
+
+			if deadLetter {
+				require.NoError(t, err)
+				require.NotNil(t, dead)
+				require.Equal(t, 2, dead.Attempts)
+				require.EqualError(t, dead.Err, "panic: boom")
+				return
+			}
+			require.EqualError(t, err, "handler for MESSAGE_CREATE failed: panic: boom")
+
+			// The event wasn't handled, so it isn't dropped as a duplicate.
+			require.Error(t, r.RouteWithMetadata(meta, "MESSAGE_CREATE", messageCreate(1, "")))
+			require.Equal(t, 4, attempts)
+			require.Equal(t, 2, plain)
+		})
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	p := &RetryPolicy{InitialInterval: 10 * time.Millisecond, MaxInterval: 50 * time.Millisecond}
+
+	want := []time.Duration{10, 20, 40, 50, 50}
+	for i, d := range want {
+		require.Equal(t, d*time.Millisecond, p.delay(i+1))
+	}
+}
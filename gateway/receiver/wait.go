@@ -0,0 +1,80 @@
+package receiver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"wumpgo.dev/wumpgo/rest"
+)
+
+// WaitFor blocks until an event of type T matching predicate is received, or
+// ctx is done. A nil predicate matches every event of type T.
+//
+//	reaction, err := receiver.WaitFor(ctx, r, func(e *objects.MessageReactionAdd) bool {
+//		return e.MessageID == msg.ID && e.Emoji.Name == "✅"
+//	})
+func WaitFor[T any](ctx context.Context, r Receiver, predicate func(*T) bool) (*T, error) {
+	ch := make(chan *T, 1)
+
+	reg, err := r.On(func(_ context.Context, _ rest.RESTClient, evt *T) {
+		if predicate != nil && !predicate(evt) {
+			return
+		}
+
+		select {
+		case ch <- evt:
+		default:
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer reg.Remove()
+
+	select {
+	case evt := <-ch:
+		return evt, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Collect gathers every event of type T matching predicate that is received
+// within d. It returns early with the events collected so far and ctx's error
+// if ctx is done first. A nil predicate matches every event of type T.
+func Collect[T any](ctx context.Context, r Receiver, d time.Duration, predicate func(*T) bool) ([]*T, error) {
+	var (
+		lock   sync.Mutex
+		events []*T
+	)
+
+	reg, err := r.On(func(_ context.Context, _ rest.RESTClient, evt *T) {
+		if predicate != nil && !predicate(evt) {
+			return
+		}
+
+		lock.Lock()
+		events = append(events, evt)
+		lock.Unlock()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		err = nil
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	reg.Remove()
+
+	lock.Lock()
+	defer lock.Unlock()
+	return events, err
+}
@@ -0,0 +1,164 @@
+package receiver
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"wumpgo.dev/wumpgo/objects"
+	"wumpgo.dev/wumpgo/rest"
+)
+
+func TestRegistration_OnceAndOff(t *testing.T) {
+	r := NewLocalReceiver()
+
+	once := 0
+	_, err := r.Once(func(_ context.Context, _ rest.RESTClient, _ *objects.MessageCreate) {
+		once++
+	})
+	require.NoError(t, err)
+
+	on := 0
+	reg, err := r.On(func(_ context.Context, _ rest.RESTClient, _ *objects.MessageCreate) {
+		on++
+	})
+	require.NoError(t, err)
+	require.Equal(t, "message_create", reg.Event())
+
+	require.NoError(t, r.Route("MESSAGE_CREATE", messageCreate(1, "")))
+	require.NoError(t, r.Route("MESSAGE_CREATE", messageCreate(1, "")))
+
+	reg.Remove()
+	reg.Remove()
+	require.NoError(t, r.Route("MESSAGE_CREATE", messageCreate(1, "")))
+
+	require.Equal(t, 1, once)
+	require.Equal(t, 2, on)
+}
+
+func TestRegistration_OnceFiltered(t *testing.T) {
+	r := NewLocalReceiver()
+
+	var got []string
+	_, err := r.Once(Filtered(func(p *Partial) bool {
+		id, _ := p.GuildID()
+		return id == 2
+	}, func(_ context.Context, _ rest.RESTClient, m *objects.MessageCreate) {
+		got = append(got, m.Content)
+	}))
+	require.NoError(t, err)
+
+	// Events the filter rejects don't use up a Once handler.
+	for _, g := range []int{1, 2, 2} {
+		require.NoError(t, r.Route("MESSAGE_CREATE", messageCreate(g, "guild "+strconv.Itoa(g))))
+	}
+
+	require.Equal(t, []string{"guild 2"}, got)
+}
+
+func TestWaitFor(t *testing.T) {
+	tests := []struct {
+		name      string
+		predicate func(*objects.MessageCreate) bool
+		timeout   time.Duration
+		want      string
+		wantErr   error
+	}{
+		{name: "first event", want: "a"},
+		{
+			name:      "matching event",
+			predicate: func(m *objects.MessageCreate) bool { return m.Content == "b" },
+			want:      "b",
+		},
+		{
+			name:      "timeout",
+			predicate: func(m *objects.MessageCreate) bool { return m.Content == "z" },
+			timeout:   50 * time.Millisecond,
+			wantErr:   context.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewLocalReceiver()
+
+			timeout := tt.timeout
+			if timeout == 0 {
+				timeout = 5 * time.Second
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			type result struct {
+				m   *objects.MessageCreate
+				err error
+			}
+			done := make(chan result, 1)
+			go func() {
+				m, err := WaitFor(ctx, r, tt.predicate)
+				done <- result{m, err}
+			}()
+
+			// Keep routing until WaitFor registered its handler and returned.
+			var res result
+			require.Eventually(t, func() bool {
+				for _, c := range []string{"a", "b"} {
+					_ = r.Route("MESSAGE_CREATE", messageCreate(1, c))
+				}
+				select {
+				case res = <-done:
+					return true
+				default:
+					return false
+				}
+			}, 5*time.Second, 10*time.Millisecond)
+
+			require.ErrorIs(t, res.err, tt.wantErr)
+			if tt.wantErr == nil {
+				require.Equal(t, tt.want, res.m.Content)
+			}
+
+			// The handler is removed once WaitFor returns.
+			require.Empty(t, r.handlersFor("message_create"))
+		})
+	}
+}
+
+func TestCollect(t *testing.T) {
+	r := NewLocalReceiver()
+
+	done := make(chan []*objects.MessageCreate, 1)
+	go func() {
+		events, err := Collect(context.Background(), r, 100*time.Millisecond, func(m *objects.MessageCreate) bool {
+			return m.GuildID == 1
+		})
+		if err == nil {
+			done <- events
+		}
+	}()
+
+	require.Eventually(t, func() bool {
+		return len(r.handlersFor("message_create")) == 1
+	}, time.Second, time.Millisecond)
+
+	for _, g := range []int{1, 2, 1} {
+		require.NoError(t, r.Route("MESSAGE_CREATE", messageCreate(g, "")))
+	}
+
+	events := receive(t, done)
+	require.Len(t, events, 2)
+	require.Empty(t, r.handlersFor("message_create"))
+}
+
+func TestCollect_Cancelled(t *testing.T) {
+	r := NewLocalReceiver()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	events, err := Collect(ctx, r, time.Minute, (func(*objects.MessageCreate) bool)(nil))
+	require.ErrorIs(t, err, context.Canceled)
+	require.Empty(t, events)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"wumpgo.dev/wumpgo/gateway/dispatcher"
 	"wumpgo.dev/wumpgo/objects"
 )
 
@@ -40,9 +41,10 @@ func (d *dispatchProcessor) process(s *Shard, p objects.Payload) error {
 		s.resume_url = ready.ResumeGatewayURL
 		s.logger.Info().Str("session_id", s.session_id).Str("user", ready.User.Username).Msg("We are ready!")
 	}
+	meta := dispatcher.Metadata{Shard: s.identify.Shard[0], Sequence: p.Sequence, Session: s.session_id}
 	go func(event string, data json.RawMessage) {
 		start := time.Now()
-		err := s.dispatcher.Dispatch(p.EventName, p.Data)
+		err := dispatcher.DispatchWithMetadata(s.dispatcher, meta, p.EventName, p.Data)
 		s.logger.Debug().Dur("duration", time.Since(start)).Str("event", p.EventName).Msg("Dispatch finished")
 		if err != nil {
 			s.logger.Err(err).Msg("Failed to dispatch")
@@ -29,11 +29,18 @@ const (
 	ChannelTypeGuildForum
 )
 
+type PermissionOverwriteType uint
+
+const (
+	PermissionOverwriteTypeRole PermissionOverwriteType = iota
+	PermissionOverwriteTypeMember
+)
+
 type PermissionOverwrite struct {
-	ID    Snowflake `json:"id"`
-	Type  uint      `json:"type"`
-	Allow string    `json:"allow"`
-	Deny  string    `json:"deny"`
+	ID    Snowflake               `json:"id"`
+	Type  PermissionOverwriteType `json:"type"`
+	Allow string                  `json:"allow"`
+	Deny  string                  `json:"deny"`
 }
 
 type Channel struct {
@@ -76,6 +83,15 @@ func (c *Channel) Mention() string {
 	return "<#" + c.ID.String() + ">"
 }
 
+// IsThread reports whether the channel is a thread.
+func (c *Channel) IsThread() bool {
+	switch c.Type {
+	case ChannelTypeAnnouncementThread, ChannelTypePublicThread, ChannelTypePrivateThread:
+		return true
+	}
+	return false
+}
+
 type ForumThreadChannel struct {
 	*Channel
 	Message *Message
@@ -156,3 +156,7 @@ const (
 	JSONErrorFailedToCreateStageNeededForStageEvent                           JSONErrorCode = 180002 // Failed to create stage needed for stage event
 )
 
+// Error implements error so codes can be used as targets for errors.Is.
+func (c JSONErrorCode) Error() string {
+	return c.String()
+}
@@ -124,6 +124,7 @@ type Guild struct {
 	VoiceStates                 []*VoiceState              `json:"voice_states,omitempty"`
 	Members                     []*GuildMember             `json:"members,omitempty"`
 	Channels                    []*Channel                 `json:"channels,omitempty"`
+	Threads                     []*Channel                 `json:"threads,omitempty"`
 	Presences                   []*PresenceUpdate          `json:"presences,omitempty"`
 	MaxPresences                int                        `json:"max_presences,omitempty"`
 	MaxMembers                  int                        `json:"max_members,omitempty"`
@@ -0,0 +1,192 @@
+package objects
+
+import (
+	"strconv"
+	"time"
+
+	"wumpgo.dev/wumpgo/objects/permissions"
+)
+
+// timedOutPermissions are the only permissions a timed out member keeps.
+const timedOutPermissions = permissions.ViewChannel | permissions.ReadMessageHistory
+
+// BasePermissions computes a member's guild wide permissions from the
+// @everyone role and the member's roles. roles are the guild's roles, the
+// guild's own Roles are used if it is nil.
+func BasePermissions(guild *Guild, roles []*Role, member *GuildMember) permissions.PermissionBit {
+	if roles == nil {
+		roles = guild.Roles
+	}
+
+	if member.User != nil && member.User.ID == guild.OwnerID {
+		return permissions.All
+	}
+
+	var perms permissions.PermissionBit
+	for _, r := range roles {
+		if r.ID == guild.ID || hasRole(member, r.ID) {
+			perms |= r.Permissions
+		}
+	}
+
+	if perms.Has(permissions.Administrator) {
+		return permissions.All
+	}
+
+	return applyTimeout(perms, member)
+}
+
+// ChannelPermissions computes a member's permissions in a channel, applying
+// the channel's permission overwrites on top of the member's base
+// permissions: first @everyone, then the member's roles and then the member.
+//
+// Threads don't have overwrites of their own, so for threads parent must be
+// the thread's parent channel. It is ignored for other channels.
+func ChannelPermissions(guild *Guild, roles []*Role, member *GuildMember, channel, parent *Channel) permissions.PermissionBit {
+	base := BasePermissions(guild, roles, member)
+	if base == permissions.All {
+		return base
+	}
+
+	overwrites := channel.PermissionOverwrites
+	if channel.IsThread() && parent != nil {
+		overwrites = parent.PermissionOverwrites
+	}
+
+	perms := OverwritePermissions(base, guild.ID, member, overwrites)
+
+	if channel.IsThread() {
+		// Sending messages in a thread is its own permission.
+		perms &^= permissions.SendMessages
+		if perms.Has(permissions.SendMessagesInThreads) {
+			perms |= permissions.SendMessages
+		}
+	}
+
+	return implicitPermissions(applyTimeout(perms, member))
+}
+
+// OverwritePermissions applies permission overwrites to base permissions in
+// Discord's order. It doesn't handle the guild owner and administrators,
+// who bypass overwrites.
+func OverwritePermissions(base permissions.PermissionBit, guildID Snowflake, member *GuildMember, overwrites []PermissionOverwrite) permissions.PermissionBit {
+	perms := base
+
+	for _, o := range overwrites {
+		if o.Type == PermissionOverwriteTypeRole && o.ID == guildID {
+			perms &^= parsePermissions(o.Deny)
+			perms |= parsePermissions(o.Allow)
+			break
+		}
+	}
+
+	var allow, deny permissions.PermissionBit
+	for _, o := range overwrites {
+		if o.Type == PermissionOverwriteTypeRole && o.ID != guildID && hasRole(member, o.ID) {
+			allow |= parsePermissions(o.Allow)
+			deny |= parsePermissions(o.Deny)
+		}
+	}
+	perms &^= deny
+	perms |= allow
+
+	if member.User != nil {
+		for _, o := range overwrites {
+			if o.Type == PermissionOverwriteTypeMember && o.ID == member.User.ID {
+				perms &^= parsePermissions(o.Deny)
+				perms |= parsePermissions(o.Allow)
+				break
+			}
+		}
+	}
+
+	return perms
+}
+
+// HighestRole returns the member's highest role, or nil if the member only
+// has @everyone.
+func HighestRole(roles []*Role, member *GuildMember) *Role {
+	var highest *Role
+	for _, r := range roles {
+		if hasRole(member, r.ID) && (highest == nil || r.Higher(highest)) {
+			highest = r
+		}
+	}
+	return highest
+}
+
+// CanModerate reports whether actor is above target in the role hierarchy,
+// which is required to kick, ban, time out or edit the roles of target.
+func CanModerate(guild *Guild, roles []*Role, actor, target *GuildMember) bool {
+	if roles == nil {
+		roles = guild.Roles
+	}
+
+	if target.User != nil && target.User.ID == guild.OwnerID {
+		return false
+	}
+	if actor.User != nil && actor.User.ID == guild.OwnerID {
+		return true
+	}
+
+	actorRole := HighestRole(roles, actor)
+	if actorRole == nil {
+		return false
+	}
+
+	targetRole := HighestRole(roles, target)
+	return targetRole == nil || actorRole.Higher(targetRole)
+}
+
+// Higher reports whether r is above other in the role hierarchy. Roles with
+// the same position are ordered by their ID, the older role is higher.
+func (r *Role) Higher(other *Role) bool {
+	if r.Position != other.Position {
+		return r.Position > other.Position
+	}
+	return r.ID < other.ID
+}
+
+// TimedOut reports whether the member is currently timed out.
+func (m *GuildMember) TimedOut() bool {
+	return m.CommunicationDisabledUntil != nil && m.CommunicationDisabledUntil.After(time.Now())
+}
+
+func hasRole(member *GuildMember, id Snowflake) bool {
+	for _, r := range member.Roles {
+		if r == id {
+			return true
+		}
+	}
+	return false
+}
+
+func applyTimeout(perms permissions.PermissionBit, member *GuildMember) permissions.PermissionBit {
+	if member.TimedOut() {
+		return perms & timedOutPermissions
+	}
+	return perms
+}
+
+// implicitPermissions removes permissions that are denied implicitly by the
+// lack of others.
+func implicitPermissions(perms permissions.PermissionBit) permissions.PermissionBit {
+	if !perms.Has(permissions.ViewChannel) {
+		return 0
+	}
+
+	if !perms.Has(permissions.SendMessages) {
+		perms &^= permissions.SendTTSMessages | permissions.MentionEveryone |
+			permissions.EmbedLinks | permissions.AttachFiles
+	}
+
+	return perms
+}
+
+func parsePermissions(s string) permissions.PermissionBit {
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return permissions.PermissionBit(v)
+}
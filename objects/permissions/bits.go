@@ -82,3 +82,6 @@ const (
 	StartEmbeddedActivities
 	ModerateMembers
 )
+
+// All is every permission, as granted to the guild owner and administrators.
+const All = ModerateMembers<<1 - 1
@@ -0,0 +1,143 @@
+package objects
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"wumpgo.dev/wumpgo/objects/permissions"
+)
+
+func TestChannelPermissions(t *testing.T) {
+	const (
+		guildID   Snowflake = 1
+		ownerID   Snowflake = 2
+		userID    Snowflake = 3
+		modRole   Snowflake = 10
+		muteRole  Snowflake = 11
+		adminRole Snowflake = 12
+	)
+
+	guild := &Guild{
+		ID:      guildID,
+		OwnerID: ownerID,
+		Roles: []*Role{
+			{ID: guildID, Permissions: permissions.ViewChannel | permissions.SendMessages | permissions.EmbedLinks},
+			{ID: modRole, Position: 2, Permissions: permissions.KickMembers},
+			{ID: muteRole, Position: 1},
+			{ID: adminRole, Position: 3, Permissions: permissions.Administrator},
+		},
+	}
+
+	text := permissions.ViewChannel | permissions.SendMessages | permissions.EmbedLinks
+	future := Time{Time: time.Now().Add(time.Hour)}
+
+	tests := []struct {
+		name    string
+		member  *GuildMember
+		channel *Channel
+		parent  *Channel
+		want    permissions.PermissionBit
+	}{
+		{
+			name:    "everyone",
+			member:  &GuildMember{User: &User{ID: userID}},
+			channel: &Channel{},
+			want:    text,
+		},
+		{
+			name:    "owner",
+			member:  &GuildMember{User: &User{ID: ownerID}},
+			channel: &Channel{PermissionOverwrites: []PermissionOverwrite{{ID: guildID, Deny: "1024"}}},
+			want:    permissions.All,
+		},
+		{
+			name:    "administrator bypasses overwrites",
+			member:  &GuildMember{User: &User{ID: userID}, Roles: []Snowflake{adminRole}},
+			channel: &Channel{PermissionOverwrites: []PermissionOverwrite{{ID: guildID, Deny: "1024"}}},
+			want:    permissions.All,
+		},
+		{
+			name:   "role allow beats role deny and everyone deny",
+			member: &GuildMember{User: &User{ID: userID}, Roles: []Snowflake{modRole, muteRole}},
+			channel: &Channel{PermissionOverwrites: []PermissionOverwrite{
+				{ID: guildID, Deny: "2048"},
+				{ID: muteRole, Deny: "2048"},
+				{ID: modRole, Allow: "2048"},
+			}},
+			want: text | permissions.KickMembers,
+		},
+		{
+			name:   "member overwrite is applied last",
+			member: &GuildMember{User: &User{ID: userID}, Roles: []Snowflake{modRole}},
+			channel: &Channel{PermissionOverwrites: []PermissionOverwrite{
+				{ID: modRole, Allow: "2048"},
+				{ID: userID, Type: PermissionOverwriteTypeMember, Deny: "2048"},
+			}},
+			want: permissions.ViewChannel | permissions.KickMembers,
+		},
+		{
+			name:    "no view channel denies everything",
+			member:  &GuildMember{User: &User{ID: userID}},
+			channel: &Channel{PermissionOverwrites: []PermissionOverwrite{{ID: guildID, Deny: "1024"}}},
+			want:    0,
+		},
+		{
+			name:    "timed out",
+			member:  &GuildMember{User: &User{ID: userID}, Roles: []Snowflake{modRole}, CommunicationDisabledUntil: &future},
+			channel: &Channel{},
+			want:    permissions.ViewChannel,
+		},
+		{
+			name:    "thread uses parent overwrites",
+			member:  &GuildMember{User: &User{ID: userID}},
+			channel: &Channel{Type: ChannelTypePublicThread},
+			parent: &Channel{PermissionOverwrites: []PermissionOverwrite{
+				{ID: guildID, Allow: "274877906944"},
+			}},
+			want: text | permissions.SendMessagesInThreads,
+		},
+		{
+			name:    "thread without send messages in threads",
+			member:  &GuildMember{User: &User{ID: userID}},
+			channel: &Channel{Type: ChannelTypePublicThread},
+			parent:  &Channel{},
+			want:    permissions.ViewChannel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ChannelPermissions(guild, nil, tt.member, tt.channel, tt.parent)
+			assert.Equal(t, tt.want, got, "got %b, want %b", got, tt.want)
+		})
+	}
+}
+
+func TestCanModerate(t *testing.T) {
+	guild := &Guild{
+		ID:      1,
+		OwnerID: 2,
+		Roles: []*Role{
+			{ID: 10, Position: 2},
+			{ID: 11, Position: 1},
+			{ID: 12, Position: 1},
+		},
+	}
+
+	owner := &GuildMember{User: &User{ID: 2}}
+	high := &GuildMember{User: &User{ID: 3}, Roles: []Snowflake{10}}
+	low := &GuildMember{User: &User{ID: 4}, Roles: []Snowflake{11}}
+	sameOlder := &GuildMember{User: &User{ID: 5}, Roles: []Snowflake{11}}
+	sameNewer := &GuildMember{User: &User{ID: 6}, Roles: []Snowflake{12}}
+	none := &GuildMember{User: &User{ID: 7}}
+
+	assert.True(t, CanModerate(guild, nil, owner, high))
+	assert.False(t, CanModerate(guild, nil, high, owner))
+	assert.True(t, CanModerate(guild, nil, high, low))
+	assert.False(t, CanModerate(guild, nil, low, high))
+	assert.False(t, CanModerate(guild, nil, low, sameOlder))
+	assert.True(t, CanModerate(guild, nil, sameOlder, sameNewer))
+	assert.True(t, CanModerate(guild, nil, low, none))
+	assert.False(t, CanModerate(guild, nil, none, none))
+}
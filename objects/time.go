@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"strconv"
 	"time"
 )
@@ -51,6 +52,14 @@ func (t *Time) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// EncodeValues encodes t as an RFC 3339 query parameter.
+func (t Time) EncodeValues(key string, v *url.Values) error {
+	if !t.IsZero() {
+		v.Set(key, t.Time.Format(time.RFC3339Nano))
+	}
+	return nil
+}
+
 func (t Time) Format(style TimestampStyle) string {
 	return fmt.Sprintf("<t:%d:%s>", t.Unix(), style)
 }
@@ -14,6 +14,7 @@ type GetAuditLogParams struct {
 	UserID     objects.Snowflake     `url:"user_id,omitempty"`
 	ActionType objects.AuditLogEvent `url:"action_type,omitempty"`
 	Before     objects.Snowflake     `url:"before,omitempty"`
+	After      objects.Snowflake     `url:"after,omitempty"`
 	Limit      int                   `url:"limit,omitempty"`
 }
 
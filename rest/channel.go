@@ -703,8 +703,8 @@ type ListThreadsResponse struct {
 }
 
 type ListThreadsParams struct {
-	Before objects.Time `json:"before,omitempty"`
-	Limit  int          `json:"limit,omitempty"`
+	Before objects.Time `url:"before,omitempty"`
+	Limit  int          `url:"limit,omitempty"`
 }
 
 func (c *Client) ListPublicArchivedThreads(ctx context.Context, channel objects.Snowflake, params ...*ListThreadsParams) (*ListThreadsResponse, error) {
@@ -712,11 +712,13 @@ func (c *Client) ListPublicArchivedThreads(ctx context.Context, channel objects.
 	if err != nil {
 		return nil, err
 	}
-	q, err := query.Values(params)
-	if err != nil {
-		return nil, err
+	if len(params) > 0 {
+		q, err := query.Values(params[0])
+		if err != nil {
+			return nil, err
+		}
+		u.RawQuery = q.Encode()
 	}
-	u.RawQuery = q.Encode()
 	threads := &ListThreadsResponse{}
 	err = NewRequest().
 		Method(http.MethodGet).
@@ -732,11 +734,13 @@ func (c *Client) ListPrivateArchivedThreads(ctx context.Context, channel objects
 	if err != nil {
 		return nil, err
 	}
-	q, err := query.Values(params)
-	if err != nil {
-		return nil, err
+	if len(params) > 0 {
+		q, err := query.Values(params[0])
+		if err != nil {
+			return nil, err
+		}
+		u.RawQuery = q.Encode()
 	}
-	u.RawQuery = q.Encode()
 	threads := &ListThreadsResponse{}
 	err = NewRequest().
 		Method(http.MethodGet).
@@ -747,16 +751,25 @@ func (c *Client) ListPrivateArchivedThreads(ctx context.Context, channel objects
 	return threads, err
 }
 
-func (c *Client) ListJoinedPrivateArchivedThreads(ctx context.Context, channel objects.Snowflake, params ...*ListThreadsParams) (*ListThreadsResponse, error) {
+// ListJoinedThreadsParams pages joined private archived threads by thread
+// ID, unlike the other archived thread endpoints.
+type ListJoinedThreadsParams struct {
+	Before objects.Snowflake `url:"before,omitempty"`
+	Limit  int               `url:"limit,omitempty"`
+}
+
+func (c *Client) ListJoinedPrivateArchivedThreads(ctx context.Context, channel objects.Snowflake, params ...*ListJoinedThreadsParams) (*ListThreadsResponse, error) {
 	u, err := url.Parse(fmt.Sprintf(ChannelUsersMeThreadsArchivedFmt, channel))
 	if err != nil {
 		return nil, err
 	}
-	q, err := query.Values(params)
-	if err != nil {
-		return nil, err
+	if len(params) > 0 {
+		q, err := query.Values(params[0])
+		if err != nil {
+			return nil, err
+		}
+		u.RawQuery = q.Encode()
 	}
-	u.RawQuery = q.Encode()
 	threads := &ListThreadsResponse{}
 	err = NewRequest().
 		Method(http.MethodGet).
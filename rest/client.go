@@ -32,6 +32,9 @@ type Client struct {
 	logger      zerolog.Logger
 	proxy       func(*http.Request) (*url.URL, error)
 	userAgent   *UserAgent
+
+	invalidRequests *invalidRequestGuard
+	retryPolicy     *RetryPolicy
 }
 
 type request struct {
@@ -100,24 +103,23 @@ func (r *request) SendRaw(c *Client) (*DiscordResponse, error) {
 			return data, nil
 		}
 	}
-	var resp *DiscordResponse
-	var err error
-	if c.rateLimiter != nil {
-		resp, err = c.rateLimiter.Request(c.httpClient, r)
-	} else {
-		resp, err = c.httpClient.Request(r)
+	var httpClient HTTPClient = c.httpClient
+	if c.invalidRequests != nil {
+		httpClient = &invalidRequestTracker{next: httpClient, guard: c.invalidRequests}
 	}
 
+	resp, err := c.retryPolicy.do(r, func() (*DiscordResponse, error) {
+		if c.rateLimiter != nil {
+			return c.rateLimiter.Request(httpClient, r)
+		}
+		return httpClient.Request(r)
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return resp, &ErrorREST{
-			Message: fmt.Sprintf("Non 2xx status code: %d (%s)", resp.StatusCode, string(resp.Body)),
-			Status:  resp.StatusCode,
-			Body:    resp.Body,
-		}
+		return resp, newErrorREST(resp)
 	}
 
 	if r.method == "GET" && c.cache != nil {
@@ -163,7 +165,9 @@ func New(options ...RestOption) RESTClient {
 			URL:     "https://wumpgo.dev",
 			Version: wumpgo.LibraryVersion(),
 		},
-		rateLimiter: NewLeakyBucketRatelimiter(),
+		rateLimiter:     NewLeakyBucketRatelimiter(),
+		invalidRequests: newInvalidRequestGuard(InvalidRequestConf{}),
+		retryPolicy:     &DefaultRetryPolicy,
 	}
 
 	for _, o := range options {
@@ -1,12 +1,35 @@
 package rest
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"wumpgo.dev/wumpgo/objects"
 )
 
+// newTestClient returns a client that sends every request to handler.
+// Handlers run on the server's goroutines and must check with assert.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c := New(WithoutRetries()).(*Client)
+	c.httpClient = &rewriteHTTPClient{next: c.httpClient, base: srv.URL}
+	return c
+}
+
+// decodeBody decodes the JSON body of a request into a map, so that missing
+// and null fields can be told apart.
+func decodeBody(t *testing.T, r *http.Request) map[string]interface{} {
+	var body map[string]interface{}
+	assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+	return body
+}
+
 func TestNew(t *testing.T) {
 	rl := NewLeakyBucketRatelimiter()
 	tests := []struct {
@@ -92,6 +92,12 @@ const (
 	GuildStickersFmt = GuildBaseFmt + "/stickers"
 	GuildStickerFmt  = GuildStickersFmt + "/%d"
 
+	// Emojis
+	GuildEmojisFmt       = GuildBaseFmt + "/emojis"
+	GuildEmojiFmt        = GuildEmojisFmt + "/%d"
+	ApplicationEmojisFmt = ApplicationFmt + "/%d/emojis"
+	ApplicationEmojiFmt  = ApplicationEmojisFmt + "/%d"
+
 	// Users
 	UsersFmt           = BaseURL + "/users"
 	UsersMeFmt         = UsersFmt + "/@me"
@@ -103,7 +109,14 @@ const (
 	UserConnections    = UsersMeFmt + "/connections"
 
 	// Voice
-	VoiceRegions = BaseURL + "/voice/regions"
+	VoiceRegions         = BaseURL + "/voice/regions"
+	GuildVoiceStatesFmt  = GuildBaseFmt + "/voice-states"
+	GuildVoiceStateMeFmt = GuildVoiceStatesFmt + "/@me"
+	GuildVoiceStateFmt   = GuildVoiceStatesFmt + "/%d"
+
+	// Stage instances
+	StageInstancesFmt = BaseURL + "/stage-instances"
+	StageInstanceFmt  = StageInstancesFmt + "/%d"
 
 	// Webhooks
 	ChannelWebhookFmt   = BaseURL + "/channels/%d/webhooks"
@@ -0,0 +1,193 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"wumpgo.dev/wumpgo/objects"
+)
+
+type CreateGuildEmojiParams struct {
+	Name string `json:"name"`
+	// Image is a data URI, see ImageData and EncodeImage.
+	Image  string              `json:"image"`
+	Roles  []objects.Snowflake `json:"roles,omitempty"`
+	Reason string              `json:"-"`
+}
+
+type ModifyGuildEmojiParams struct {
+	Name string `json:"name,omitempty"`
+	// Roles limits the emoji to these roles, an empty slice allows every
+	// role and nil leaves them unchanged.
+	Roles  *[]objects.Snowflake `json:"roles,omitempty"`
+	Reason string               `json:"-"`
+}
+
+func (c *Client) ListGuildEmojis(ctx context.Context, guild objects.Snowflake) ([]*objects.Emoji, error) {
+	var emojis []*objects.Emoji
+	err := NewRequest().
+		Method(http.MethodGet).
+		WithContext(ctx).
+		Path(fmt.Sprintf(GuildEmojisFmt, guild)).
+		ContentType(JsonContentType).
+		Bind(&emojis).
+		Send(c)
+
+	return emojis, err
+}
+
+func (c *Client) GetGuildEmoji(ctx context.Context, guild, emoji objects.Snowflake) (*objects.Emoji, error) {
+	e := &objects.Emoji{}
+	err := NewRequest().
+		Method(http.MethodGet).
+		WithContext(ctx).
+		Path(fmt.Sprintf(GuildEmojiFmt, guild, emoji)).
+		ContentType(JsonContentType).
+		Bind(e).
+		Send(c)
+
+	return e, err
+}
+
+func (c *Client) CreateGuildEmoji(ctx context.Context, guild objects.Snowflake, params *CreateGuildEmojiParams) (*objects.Emoji, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	emoji := &objects.Emoji{}
+	err = NewRequest().
+		Method(http.MethodPost).
+		WithContext(ctx).
+		Path(fmt.Sprintf(GuildEmojisFmt, guild)).
+		ContentType(JsonContentType).
+		Body(data).
+		Bind(emoji).
+		Reason(params.Reason).
+		Send(c)
+
+	return emoji, err
+}
+
+func (c *Client) ModifyGuildEmoji(ctx context.Context, guild, emoji objects.Snowflake, params *ModifyGuildEmojiParams) (*objects.Emoji, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &objects.Emoji{}
+	err = NewRequest().
+		Method(http.MethodPatch).
+		WithContext(ctx).
+		Path(fmt.Sprintf(GuildEmojiFmt, guild, emoji)).
+		ContentType(JsonContentType).
+		Body(data).
+		Bind(e).
+		Reason(params.Reason).
+		Send(c)
+
+	return e, err
+}
+
+func (c *Client) DeleteGuildEmoji(ctx context.Context, guild, emoji objects.Snowflake, reason ...string) error {
+	req := NewRequest().
+		Method(http.MethodDelete).
+		WithContext(ctx).
+		Path(fmt.Sprintf(GuildEmojiFmt, guild, emoji)).
+		ContentType(JsonContentType)
+
+	if len(reason) > 0 {
+		req.Reason(reason[0])
+	}
+
+	return req.Send(c)
+}
+
+type CreateApplicationEmojiParams struct {
+	Name string `json:"name"`
+	// Image is a data URI, see ImageData and EncodeImage.
+	Image string `json:"image"`
+}
+
+type ModifyApplicationEmojiParams struct {
+	Name string `json:"name"`
+}
+
+type listApplicationEmojisResponse struct {
+	Items []*objects.Emoji `json:"items"`
+}
+
+func (c *Client) ListApplicationEmojis(ctx context.Context, application objects.Snowflake) ([]*objects.Emoji, error) {
+	resp := &listApplicationEmojisResponse{}
+	err := NewRequest().
+		Method(http.MethodGet).
+		WithContext(ctx).
+		Path(fmt.Sprintf(ApplicationEmojisFmt, application)).
+		ContentType(JsonContentType).
+		Bind(resp).
+		Send(c)
+
+	return resp.Items, err
+}
+
+func (c *Client) GetApplicationEmoji(ctx context.Context, application, emoji objects.Snowflake) (*objects.Emoji, error) {
+	e := &objects.Emoji{}
+	err := NewRequest().
+		Method(http.MethodGet).
+		WithContext(ctx).
+		Path(fmt.Sprintf(ApplicationEmojiFmt, application, emoji)).
+		ContentType(JsonContentType).
+		Bind(e).
+		Send(c)
+
+	return e, err
+}
+
+func (c *Client) CreateApplicationEmoji(ctx context.Context, application objects.Snowflake, params *CreateApplicationEmojiParams) (*objects.Emoji, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	emoji := &objects.Emoji{}
+	err = NewRequest().
+		Method(http.MethodPost).
+		WithContext(ctx).
+		Path(fmt.Sprintf(ApplicationEmojisFmt, application)).
+		ContentType(JsonContentType).
+		Body(data).
+		Bind(emoji).
+		Send(c)
+
+	return emoji, err
+}
+
+func (c *Client) ModifyApplicationEmoji(ctx context.Context, application, emoji objects.Snowflake, params *ModifyApplicationEmojiParams) (*objects.Emoji, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &objects.Emoji{}
+	err = NewRequest().
+		Method(http.MethodPatch).
+		WithContext(ctx).
+		Path(fmt.Sprintf(ApplicationEmojiFmt, application, emoji)).
+		ContentType(JsonContentType).
+		Body(data).
+		Bind(e).
+		Send(c)
+
+	return e, err
+}
+
+func (c *Client) DeleteApplicationEmoji(ctx context.Context, application, emoji objects.Snowflake) error {
+	return NewRequest().
+		Method(http.MethodDelete).
+		WithContext(ctx).
+		Path(fmt.Sprintf(ApplicationEmojiFmt, application, emoji)).
+		ContentType(JsonContentType).
+		Send(c)
+}
@@ -0,0 +1,98 @@
+package rest
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wumpgo.dev/wumpgo/objects"
+)
+
+func TestEncodeImage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.White)
+
+	uri, err := EncodeImage(img)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(uri, "data:image/png;base64,"))
+
+	require.True(t, strings.HasPrefix(ImageData([]byte("GIF89a")), "data:image/gif;base64,"))
+}
+
+func TestCreateGuildEmoji(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v10/guilds/686053040863969305/emojis", r.URL.Path)
+		assert.Equal(t, "syncing emojis", r.Header.Get(XAuditLogReasonHeader))
+
+		body := decodeBody(t, r)
+		assert.Equal(t, "wumpus", body["name"])
+		assert.Equal(t, ImageData([]byte("GIF89a")), body["image"])
+		assert.NotContains(t, body, "Reason")
+
+		_, _ = w.Write([]byte(`{"id": "872142459847733259", "name": "wumpus"}`))
+	})
+
+	emoji, err := c.CreateGuildEmoji(context.Background(), 686053040863969305, &CreateGuildEmojiParams{
+		Name:   "wumpus",
+		Image:  ImageData([]byte("GIF89a")),
+		Reason: "syncing emojis",
+	})
+	require.NoError(t, err)
+	require.Equal(t, objects.Snowflake(872142459847733259), emoji.ID)
+}
+
+func TestModifyGuildEmoji_Roles(t *testing.T) {
+	tt := []struct {
+		name  string
+		roles *[]objects.Snowflake
+		want  interface{}
+	}{
+		{name: "unchanged"},
+		{name: "cleared", roles: &[]objects.Snowflake{}, want: []interface{}{}},
+		{name: "set", roles: &[]objects.Snowflake{1}, want: []interface{}{"1"}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPatch, r.Method)
+
+				body := decodeBody(t, r)
+				if tc.want == nil {
+					assert.NotContains(t, body, "roles")
+				} else {
+					assert.Equal(t, tc.want, body["roles"])
+				}
+
+				_, _ = w.Write([]byte(`{"id": "872142459847733259", "name": "wumpus"}`))
+			})
+
+			_, err := c.ModifyGuildEmoji(context.Background(), 686053040863969305, 872142459847733259, &ModifyGuildEmojiParams{
+				Name:  "wumpus",
+				Roles: tc.roles,
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestListApplicationEmojis(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/v10/applications/686053040863969305/emojis", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"items": [{"id": "1", "name": "a"}, {"id": "2", "name": "b"}]}`))
+	})
+
+	emojis, err := c.ListApplicationEmojis(context.Background(), 686053040863969305)
+	require.NoError(t, err)
+	require.Len(t, emojis, 2)
+	require.Equal(t, objects.Snowflake(2), emojis[1].ID)
+	require.Equal(t, "b", emojis[1].Name)
+}
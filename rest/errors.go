@@ -0,0 +1,184 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"wumpgo.dev/wumpgo/objects"
+)
+
+// ErrorREST is returned for any non 2xx response from Discord.
+//
+// When the body is a Discord JSON error, Code, Message and Errors are
+// decoded from it, so callers can match on the error code with
+// errors.Is(err, objects.JSONErrorUnknownMessage).
+type ErrorREST struct {
+	// Message is the message sent by Discord, or the raw body if it could
+	// not be decoded.
+	Message string
+	Status  int
+	Body    json.RawMessage
+	// Code is the Discord JSON error code, JSONErrorGeneralError if the
+	// body was not a Discord error.
+	Code objects.JSONErrorCode
+	// Errors holds the field level validation errors, sorted by path.
+	Errors []FieldError
+}
+
+// FieldError is a single validation error from the errors tree of an
+// Invalid Form Body response.
+type FieldError struct {
+	// Path is the dotted path to the offending field, e.g. "embeds.0.title".
+	Path    string
+	Code    string
+	Message string
+}
+
+func (f FieldError) String() string {
+	return fmt.Sprintf("%s: %s (%s)", f.Path, f.Message, f.Code)
+}
+
+type jsonError struct {
+	Code    *objects.JSONErrorCode `json:"code"`
+	Message string                 `json:"message"`
+	Errors  json.RawMessage        `json:"errors"`
+}
+
+func newErrorREST(resp *DiscordResponse) *ErrorREST {
+	e := &ErrorREST{
+		Message: string(resp.Body),
+		Status:  resp.StatusCode,
+		Body:    resp.Body,
+	}
+
+	var body jsonError
+	if err := json.Unmarshal(resp.Body, &body); err != nil || body.Code == nil {
+		return e
+	}
+
+	e.Code = *body.Code
+	e.Message = body.Message
+	if len(body.Errors) > 0 {
+		e.Errors = parseFieldErrors(body.Errors)
+	}
+
+	return e
+}
+
+// parseFieldErrors flattens Discord's nested errors object. Leaves are
+// objects with an "_errors" array, every other key is a path segment.
+func parseFieldErrors(data json.RawMessage) []FieldError {
+	var out []FieldError
+	var walk func(path []string, data json.RawMessage)
+	walk = func(path []string, data json.RawMessage) {
+		var node map[string]json.RawMessage
+		if err := json.Unmarshal(data, &node); err != nil {
+			return
+		}
+		for key, value := range node {
+			if key != "_errors" {
+				walk(append(path[:len(path):len(path)], key), value)
+				continue
+			}
+			var errs []struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			}
+			if err := json.Unmarshal(value, &errs); err != nil {
+				continue
+			}
+			for _, fe := range errs {
+				out = append(out, FieldError{
+					Path:    strings.Join(path, "."),
+					Code:    fe.Code,
+					Message: fe.Message,
+				})
+			}
+		}
+	}
+	walk(nil, data)
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Path < out[j].Path
+	})
+
+	return out
+}
+
+func (r ErrorREST) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "discord: %d %s", r.Status, r.Message)
+	if r.Code != objects.JSONErrorGeneralError {
+		fmt.Fprintf(&sb, " (code %d)", uint(r.Code))
+	}
+	for _, fe := range r.Errors {
+		sb.WriteString("; ")
+		sb.WriteString(fe.String())
+	}
+	return sb.String()
+}
+
+// Is reports whether target is the JSON error code of r.
+func (r ErrorREST) Is(target error) bool {
+	code, ok := target.(objects.JSONErrorCode)
+	return ok && code == r.Code
+}
+
+// FieldError returns the validation errors for the field at path.
+func (r ErrorREST) FieldError(path string) []FieldError {
+	var out []FieldError
+	for _, fe := range r.Errors {
+		if fe.Path == path {
+			out = append(out, fe)
+		}
+	}
+	return out
+}
+
+// AsErrorREST returns the ErrorREST in err's chain, if any.
+func AsErrorREST(err error) (*ErrorREST, bool) {
+	var ptr *ErrorREST
+	if errors.As(err, &ptr) {
+		return ptr, true
+	}
+	var val ErrorREST
+	if errors.As(err, &val) {
+		return &val, true
+	}
+	return nil, false
+}
+
+// ErrorCode returns the Discord JSON error code of err, if it is an ErrorREST.
+func ErrorCode(err error) (objects.JSONErrorCode, bool) {
+	e, ok := AsErrorREST(err)
+	if !ok {
+		return 0, false
+	}
+	return e.Code, true
+}
+
+// IsMissingPermissions reports whether err was caused by the bot lacking
+// permissions or access to the resource.
+func IsMissingPermissions(err error) bool {
+	return errors.Is(err, objects.JSONErrorYouLackPermissionsToPerformThatAction) ||
+		errors.Is(err, objects.JSONErrorMissingAccess)
+}
+
+// IsUnknownResource reports whether err was caused by a resource that does
+// not exist, such as a deleted message or channel.
+func IsUnknownResource(err error) bool {
+	e, ok := AsErrorREST(err)
+	if !ok {
+		return false
+	}
+	return e.Code >= 10000 && e.Code < 20000
+}
+
+// IsNotFound reports whether err is a 404 response.
+func IsNotFound(err error) bool {
+	e, ok := AsErrorREST(err)
+	return ok && e.Status == 404
+}
@@ -0,0 +1,92 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"wumpgo.dev/wumpgo/objects"
+)
+
+func TestErrorREST(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name   string
+		status int
+		body   string
+
+		wantCode    objects.JSONErrorCode
+		wantMessage string
+		wantErrors  []FieldError
+		wantIs      error
+		wantUnknown bool
+		wantPerms   bool
+	}{
+		{
+			name:        "unknown message",
+			status:      404,
+			body:        `{"message": "Unknown Message", "code": 10008}`,
+			wantCode:    objects.JSONErrorUnknownMessage,
+			wantMessage: "Unknown Message",
+			wantIs:      objects.JSONErrorUnknownMessage,
+			wantUnknown: true,
+		},
+		{
+			name:        "missing permissions",
+			status:      403,
+			body:        `{"message": "Missing Permissions", "code": 50013}`,
+			wantCode:    objects.JSONErrorYouLackPermissionsToPerformThatAction,
+			wantMessage: "Missing Permissions",
+			wantIs:      objects.JSONErrorYouLackPermissionsToPerformThatAction,
+			wantPerms:   true,
+		},
+		{
+			name:   "invalid form body",
+			status: 400,
+			body: `{"code": 50035, "message": "Invalid Form Body", "errors": {
+				"embeds": {"0": {"title": {"_errors": [{"code": "BASE_TYPE_REQUIRED", "message": "This field is required"}]}}},
+				"content": {"_errors": [{"code": "BASE_TYPE_MAX_LENGTH", "message": "Must be 2000 or fewer in length."}]}
+			}}`,
+			wantCode:    objects.JSONErrorInvalidFormBody,
+			wantMessage: "Invalid Form Body",
+			wantErrors: []FieldError{
+				{Path: "content", Code: "BASE_TYPE_MAX_LENGTH", Message: "Must be 2000 or fewer in length."},
+				{Path: "embeds.0.title", Code: "BASE_TYPE_REQUIRED", Message: "This field is required"},
+			},
+			wantIs: objects.JSONErrorInvalidFormBody,
+		},
+		{
+			name:        "not json",
+			status:      502,
+			body:        `<html>Bad Gateway</html>`,
+			wantCode:    objects.JSONErrorGeneralError,
+			wantMessage: "<html>Bad Gateway</html>",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var err error = newErrorREST(&DiscordResponse{StatusCode: tc.status, Body: []byte(tc.body)})
+			err = fmt.Errorf("wrapped: %w", err)
+
+			e, ok := AsErrorREST(err)
+			require.True(t, ok)
+			require.Equal(t, tc.status, e.Status)
+			require.Equal(t, tc.wantCode, e.Code)
+			require.Equal(t, tc.wantMessage, e.Message)
+			require.Equal(t, tc.wantErrors, e.Errors)
+
+			if tc.wantIs != nil {
+				require.True(t, errors.Is(err, tc.wantIs))
+			}
+			require.False(t, errors.Is(err, objects.JSONErrorUnknownChannel))
+			require.Equal(t, tc.wantUnknown, IsUnknownResource(err))
+			require.Equal(t, tc.wantPerms, IsMissingPermissions(err))
+		})
+	}
+}
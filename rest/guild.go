@@ -391,12 +391,31 @@ func (c *Client) RemoveGuildMember(ctx context.Context, guild, user objects.Snow
 		Send(c)
 }
 
-func (c *Client) GetGuildBans(ctx context.Context, guild objects.Snowflake) ([]*objects.Ban, error) {
+type GetGuildBansParams struct {
+	Limit  int               `url:"limit,omitempty"`
+	Before objects.Snowflake `url:"before,omitempty"`
+	After  objects.Snowflake `url:"after,omitempty"`
+}
+
+func (c *Client) GetGuildBans(ctx context.Context, guild objects.Snowflake, params ...*GetGuildBansParams) ([]*objects.Ban, error) {
+	u, err := url.Parse(fmt.Sprintf(GuildBansFmt, guild))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(params) > 0 {
+		v, err := query.Values(params[0])
+		if err != nil {
+			return nil, err
+		}
+		u.RawQuery = v.Encode()
+	}
+
 	bans := []*objects.Ban{}
-	err := NewRequest().
+	err = NewRequest().
 		Method(http.MethodGet).
 		WithContext(ctx).
-		Path(fmt.Sprintf(GuildBansFmt, guild)).
+		Path(u.String()).
 		ContentType(JsonContentType).
 		Bind(&bans).
 		Send(c)
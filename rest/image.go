@@ -0,0 +1,25 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"net/http"
+)
+
+// ImageData encodes raw image bytes as a data URI, as expected by fields
+// such as emoji images, icons and avatars. The content type is detected
+// from the data.
+func ImageData(data []byte) string {
+	return "data:" + http.DetectContentType(data) + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// EncodeImage encodes img as a PNG data URI, see ImageData.
+func EncodeImage(img image.Image) (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return ImageData(buf.Bytes()), nil
+}
@@ -21,6 +21,7 @@ type RESTClient interface {
 	BulkDeleteMessages(context.Context, objects.Snowflake, *DeleteMessagesParams) error
 	BulkOverwriteGlobalCommands(context.Context, objects.Snowflake, []*objects.ApplicationCommand) ([]*objects.ApplicationCommand, error)
 	BulkOverwriteGuildCommands(context.Context, objects.Snowflake, objects.Snowflake, []*objects.ApplicationCommand) ([]*objects.ApplicationCommand, error)
+	CreateApplicationEmoji(context.Context, objects.Snowflake, *CreateApplicationEmojiParams) (*objects.Emoji, error)
 	CreateAutoModerationRule(context.Context, objects.Snowflake, *CreateAutoModerationRuleParams) (*objects.AutoModerationRule, error)
 	CreateBan(context.Context, objects.Snowflake, objects.Snowflake, *CreateGuildBanParams) error
 	CreateChannelInvite(context.Context, objects.Snowflake, *CreateInviteParams) (*objects.Invite, error)
@@ -30,6 +31,7 @@ type RESTClient interface {
 	CreateGroupDM(context.Context, *CreateGroupDMParams) (*objects.Channel, error)
 	CreateGuild(context.Context, *CreateGuildParams) (*objects.Guild, error)
 	CreateGuildChannel(context.Context, objects.Snowflake, *ChannelCreateParams) (*objects.Channel, error)
+	CreateGuildEmoji(context.Context, objects.Snowflake, *CreateGuildEmojiParams) (*objects.Emoji, error)
 	CreateGuildFromTemplate(context.Context, string, string) (*objects.Guild, error)
 	CreateGuildRole(context.Context, objects.Snowflake, *CreateGuildRoleParams) (*objects.Role, error)
 	CreateGuildScheduledEvent(context.Context, objects.Snowflake, *CreateGuildScheduledEventParams) (*objects.GuildScheduledEvent, error)
@@ -38,9 +40,11 @@ type RESTClient interface {
 	CreateInteractionResponse(context.Context, objects.Snowflake, string, *objects.InteractionResponse) error
 	CreateMessage(context.Context, objects.Snowflake, *CreateMessageParams) (*objects.Message, error)
 	CreateReaction(context.Context, objects.Snowflake, objects.Snowflake, interface {}) error
+	CreateStageInstance(context.Context, *CreateStageInstanceParams) (*objects.StageInstance, error)
 	CreateWebhook(context.Context, objects.Snowflake, *CreateWebhookParams) (*objects.Webhook, error)
 	CrossPostMessage(context.Context, objects.Snowflake, objects.Snowflake) (*objects.Message, error)
 	DeleteAllReactions(context.Context, objects.Snowflake, objects.Snowflake) error
+	DeleteApplicationEmoji(context.Context, objects.Snowflake, objects.Snowflake) error
 	DeleteAutoModerationRule(context.Context, objects.Snowflake, objects.Snowflake, ...string) error
 	DeleteChannel(context.Context, objects.Snowflake, string) (*objects.Channel, error)
 	DeleteChannelPermission(context.Context, objects.Snowflake, objects.Snowflake, string) error
@@ -49,6 +53,7 @@ type RESTClient interface {
 	DeleteFollowupMessage(context.Context, objects.Snowflake, string, objects.Snowflake) error
 	DeleteGuild(context.Context, objects.Snowflake) error
 	DeleteGuildCommand(context.Context, objects.Snowflake, objects.Snowflake, objects.Snowflake) error
+	DeleteGuildEmoji(context.Context, objects.Snowflake, objects.Snowflake, ...string) error
 	DeleteGuildIntegration(context.Context, objects.Snowflake, objects.Snowflake, string) error
 	DeleteGuildRole(context.Context, objects.Snowflake, objects.Snowflake, string) error
 	DeleteGuildScheduledEvent(context.Context, objects.Snowflake, objects.Snowflake) error
@@ -59,6 +64,7 @@ type RESTClient interface {
 	DeleteOriginalInteractionResponse(context.Context, objects.Snowflake, string) error
 	DeleteOwnReaction(context.Context, objects.Snowflake, objects.Snowflake, interface {}) error
 	DeletePinnedMessage(context.Context, objects.Snowflake, objects.Snowflake) error
+	DeleteStageInstance(context.Context, objects.Snowflake, ...string) error
 	DeleteUserReaction(context.Context, objects.Snowflake, objects.Snowflake, objects.Snowflake, interface {}) error
 	DeleteWebhook(context.Context, objects.Snowflake) error
 	DeleteWebhookMessage(context.Context, objects.Snowflake, objects.Snowflake, string) error
@@ -74,6 +80,7 @@ type RESTClient interface {
 	Gateway(context.Context) (*objects.Gateway, error)
 	GatewayBot(context.Context) (*objects.Gateway, error)
 	GetApplicationCommandPermissions(context.Context, objects.Snowflake, objects.Snowflake, objects.Snowflake) (*objects.GuildApplicationCommandPermissions, error)
+	GetApplicationEmoji(context.Context, objects.Snowflake, objects.Snowflake) (*objects.Emoji, error)
 	GetApplicationRoleConnectionMetadataRecords(context.Context, objects.Snowflake) ([]*objects.ApplicationRoleConnectionMetadata, error)
 	GetAuditLogs(context.Context, objects.Snowflake, *GetAuditLogParams) (*objects.AuditLog, error)
 	GetAutoModerationRule(context.Context, objects.Snowflake, objects.Snowflake) (*objects.AutoModerationRule, error)
@@ -92,10 +99,11 @@ type RESTClient interface {
 	GetGuild(context.Context, objects.Snowflake) (*objects.Guild, error)
 	GetGuildApplicationCommandPermissions(context.Context, objects.Snowflake, objects.Snowflake) ([]*objects.GuildApplicationCommandPermissions, error)
 	GetGuildBan(context.Context, objects.Snowflake, objects.Snowflake) (*objects.Ban, error)
-	GetGuildBans(context.Context, objects.Snowflake) ([]*objects.Ban, error)
+	GetGuildBans(context.Context, objects.Snowflake, ...*GetGuildBansParams) ([]*objects.Ban, error)
 	GetGuildChannels(context.Context, objects.Snowflake) ([]*objects.Channel, error)
 	GetGuildCommand(context.Context, objects.Snowflake, objects.Snowflake, objects.Snowflake) (*objects.ApplicationCommand, error)
 	GetGuildCommands(context.Context, objects.Snowflake, objects.Snowflake) ([]*objects.ApplicationCommand, error)
+	GetGuildEmoji(context.Context, objects.Snowflake, objects.Snowflake) (*objects.Emoji, error)
 	GetGuildIntegrations(context.Context, objects.Snowflake) ([]*objects.Integration, error)
 	GetGuildInvites(context.Context, objects.Snowflake) ([]*objects.Invite, error)
 	GetGuildMember(context.Context, objects.Snowflake, objects.Snowflake) (*objects.GuildMember, error)
@@ -117,6 +125,7 @@ type RESTClient interface {
 	GetOriginalInteractionResponse(context.Context, objects.Snowflake, string) (*objects.Message, error)
 	GetPinnedMessages(context.Context, objects.Snowflake) ([]*objects.Message, error)
 	GetReactions(context.Context, objects.Snowflake, objects.Snowflake, interface {}, *GetReactionsParams) ([]*objects.User, error)
+	GetStageInstance(context.Context, objects.Snowflake) (*objects.StageInstance, error)
 	GetSticker(context.Context, objects.Snowflake) (*objects.Sticker, error)
 	GetTemplate(context.Context, string) (*objects.Template, error)
 	GetThreadMember(context.Context, objects.Snowflake, objects.Snowflake) (*objects.ThreadMember, error)
@@ -131,19 +140,24 @@ type RESTClient interface {
 	LeaveGuild(context.Context, objects.Snowflake) error
 	LeaveThread(context.Context, objects.Snowflake) error
 	ListActiveThreads(context.Context, objects.Snowflake) ([]*ListThreadsResponse, error)
+	ListApplicationEmojis(context.Context, objects.Snowflake) ([]*objects.Emoji, error)
+	ListGuildEmojis(context.Context, objects.Snowflake) ([]*objects.Emoji, error)
 	ListGuildMembers(context.Context, objects.Snowflake, *ListGuildMembersParams) ([]*objects.GuildMember, error)
 	ListGuildStickers(context.Context, objects.Snowflake) ([]*objects.Sticker, error)
-	ListJoinedPrivateArchivedThreads(context.Context, objects.Snowflake, ...*ListThreadsParams) (*ListThreadsResponse, error)
+	ListJoinedPrivateArchivedThreads(context.Context, objects.Snowflake, ...*ListJoinedThreadsParams) (*ListThreadsResponse, error)
 	ListNitroStickerPacks(context.Context) ([]*objects.StickerPack, error)
 	ListPrivateArchivedThreads(context.Context, objects.Snowflake, ...*ListThreadsParams) (*ListThreadsResponse, error)
 	ListPublicArchivedThreads(context.Context, objects.Snowflake, ...*ListThreadsParams) (*ListThreadsResponse, error)
 	ListThreadMembers(context.Context, objects.Snowflake) ([]*objects.ThreadMember, error)
+	ModifyApplicationEmoji(context.Context, objects.Snowflake, objects.Snowflake, *ModifyApplicationEmojiParams) (*objects.Emoji, error)
 	ModifyAutoModerationRule(context.Context, objects.Snowflake, objects.Snowflake, *ModifyAutoModerationRuleParams) (*objects.AutoModerationRule, error)
 	ModifyChannel(context.Context, objects.Snowflake, *ModifyChannelParams) (*objects.Channel, error)
 	ModifyCurrentUser(context.Context, *ModifyCurrentUserParams) (*objects.User, error)
 	ModifyCurrentUserNick(context.Context, objects.Snowflake, *ModifyCurrentUserNickParams) (*ModifyCurrentUserNickParams, error)
+	ModifyCurrentUserVoiceState(context.Context, objects.Snowflake, *ModifyCurrentUserVoiceStateParams) error
 	ModifyGuild(context.Context, objects.Snowflake, *ModifyGuildParams) (*objects.Guild, error)
 	ModifyGuildChannelPositions(context.Context, objects.Snowflake, []*ModifyChannelPositionParams, string) error
+	ModifyGuildEmoji(context.Context, objects.Snowflake, objects.Snowflake, *ModifyGuildEmojiParams) (*objects.Emoji, error)
 	ModifyGuildMember(context.Context, objects.Snowflake, objects.Snowflake, *ModifyGuildMemberParams) (*objects.GuildMember, error)
 	ModifyGuildRole(context.Context, objects.Snowflake, objects.Snowflake, *ModifyGuildRoleParams) (*objects.Role, error)
 	ModifyGuildRolePositions(context.Context, objects.Snowflake, []*ModifyGuildRolePositionsParams) ([]*objects.Role, error)
@@ -152,6 +166,8 @@ type RESTClient interface {
 	ModifyGuildTemplate(context.Context, objects.Snowflake, string, *ModifyGuildTemplateParams) (*objects.Template, error)
 	ModifyGuildWelcomeScreen(context.Context, objects.Snowflake, *ModifyGuildMembershipScreeningParams) (*objects.MembershipScreening, error)
 	ModifyGuildWidget(context.Context, objects.Snowflake, *GuildWidgetParams) (*objects.GuildWidget, error)
+	ModifyStageInstance(context.Context, objects.Snowflake, *ModifyStageInstanceParams) (*objects.StageInstance, error)
+	ModifyUserVoiceState(context.Context, objects.Snowflake, objects.Snowflake, *ModifyUserVoiceStateParams) error
 	ModifyWebhook(context.Context, objects.Snowflake, *ModifyWebhookParams) (*objects.Webhook, error)
 	ModifyWebhookWithToken(context.Context, objects.Snowflake, string, *ModifyWebhookWithTokenParams) (*objects.Webhook, error)
 	RemoveGuildBan(context.Context, objects.Snowflake, objects.Snowflake, string) error
@@ -0,0 +1,267 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+var (
+	_ InvalidRequestCounter = (*MemoryInvalidRequestCounter)(nil)
+	_ InvalidRequestCounter = (*RedisInvalidRequestCounter)(nil)
+	_ HTTPClient            = (*invalidRequestTracker)(nil)
+)
+
+const (
+	// DefaultInvalidRequestLimit is the number of invalid responses Discord
+	// allows per DefaultInvalidRequestWindow before banning the IP.
+	DefaultInvalidRequestLimit  = 10000
+	DefaultInvalidRequestWindow = 10 * time.Minute
+
+	invalidRequestRefresh = time.Second
+)
+
+// InvalidRequestCounter counts invalid (401, 403 and 429) responses over a
+// sliding window.
+type InvalidRequestCounter interface {
+	// Add records an invalid response and returns the number recorded over
+	// the last window.
+	Add(ctx context.Context, window time.Duration) (int, error)
+	// Count returns the number of invalid responses over the last window.
+	Count(ctx context.Context, window time.Duration) (int, error)
+}
+
+// InvalidRequestConf configures the invalid request circuit breaker.
+type InvalidRequestConf struct {
+	// Counter keeps track of invalid responses, use a RedisInvalidRequestCounter
+	// to share the count between processes using the same IP.
+	// Defaults to a MemoryInvalidRequestCounter.
+	Counter InvalidRequestCounter
+	// Window defaults to DefaultInvalidRequestWindow.
+	Window time.Duration
+	// Limit defaults to DefaultInvalidRequestLimit.
+	Limit int
+	// BreakAt is the count at which requests start failing with
+	// ErrorInvalidRequestLimit. Defaults to 90% of Limit.
+	BreakAt int
+	// WarnAt is the count at which OnWarn is called. Defaults to 75% of Limit.
+	WarnAt int
+	// OnWarn is called once each time the count reaches WarnAt and when
+	// the breaker opens.
+	OnWarn func(count, limit int)
+}
+
+// ErrorInvalidRequestLimit is returned instead of sending a request while
+// too many invalid responses were received recently.
+type ErrorInvalidRequestLimit struct {
+	Count int
+	Limit int
+}
+
+func (e *ErrorInvalidRequestLimit) Error() string {
+	return fmt.Sprintf("invalid request limit reached: %d/%d invalid responses, refusing to send request", e.Count, e.Limit)
+}
+
+type invalidRequestGuard struct {
+	conf InvalidRequestConf
+
+	mu        sync.Mutex
+	count     int
+	refreshed time.Time
+	warned    bool
+	open      bool
+}
+
+func newInvalidRequestGuard(conf InvalidRequestConf) *invalidRequestGuard {
+	if conf.Window <= 0 {
+		conf.Window = DefaultInvalidRequestWindow
+	}
+	if conf.Limit <= 0 {
+		conf.Limit = DefaultInvalidRequestLimit
+	}
+	if conf.BreakAt <= 0 {
+		conf.BreakAt = conf.Limit * 9 / 10
+	}
+	if conf.WarnAt <= 0 {
+		conf.WarnAt = conf.Limit * 3 / 4
+	}
+	if conf.Counter == nil {
+		conf.Counter = NewMemoryInvalidRequestCounter()
+	}
+	return &invalidRequestGuard{conf: conf}
+}
+
+// allow reports whether a request may be sent, refreshing the shared count
+// at most once a second.
+func (g *invalidRequestGuard) allow(ctx context.Context) error {
+	g.mu.Lock()
+	if time.Since(g.refreshed) >= invalidRequestRefresh {
+		g.refreshed = time.Now()
+		g.mu.Unlock()
+		count, err := g.conf.Counter.Count(ctx, g.conf.Window)
+		if err != nil {
+			// Fail open, a broken counter shouldn't take the bot down.
+			zerolog.Ctx(ctx).Warn().Err(err).Msg("Failed to count invalid requests")
+		} else {
+			g.set(count)
+		}
+		g.mu.Lock()
+	}
+	defer g.mu.Unlock()
+
+	if g.open {
+		return &ErrorInvalidRequestLimit{Count: g.count, Limit: g.conf.Limit}
+	}
+	return nil
+}
+
+func (g *invalidRequestGuard) record(ctx context.Context) {
+	count, err := g.conf.Counter.Add(ctx, g.conf.Window)
+	if err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("Failed to record invalid request")
+		return
+	}
+	g.set(count)
+}
+
+// set updates the count and the breaker state, calling OnWarn if a
+// threshold was crossed.
+func (g *invalidRequestGuard) set(count int) {
+	g.mu.Lock()
+	g.count = count
+	g.refreshed = time.Now()
+
+	warn := false
+	if count >= g.conf.WarnAt {
+		warn = !g.warned
+		g.warned = true
+	} else {
+		g.warned = false
+	}
+
+	open := count >= g.conf.BreakAt
+	if open && !g.open {
+		warn = true
+	}
+	g.open = open
+	g.mu.Unlock()
+
+	if warn && g.conf.OnWarn != nil {
+		g.conf.OnWarn(count, g.conf.Limit)
+	}
+}
+
+// isInvalidResponse reports whether Discord counts resp towards the invalid
+// request limit. Shared rate limits are excluded.
+func isInvalidResponse(resp *DiscordResponse) bool {
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return true
+	case http.StatusTooManyRequests:
+		return resp.Header.Get("X-RateLimit-Scope") != "shared"
+	}
+	return false
+}
+
+// invalidRequestTracker wraps the HTTP client so every attempt made by the
+// rate limiter, including retries, goes through the guard.
+type invalidRequestTracker struct {
+	next  HTTPClient
+	guard *invalidRequestGuard
+}
+
+func (t *invalidRequestTracker) Request(req *request) (*DiscordResponse, error) {
+	ctx := req.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if err := t.guard.allow(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.Request(req)
+	if resp != nil && isInvalidResponse(resp) {
+		t.guard.record(ctx)
+	}
+	return resp, err
+}
+
+// MemoryInvalidRequestCounter counts invalid responses in per second slots.
+type MemoryInvalidRequestCounter struct {
+	mu    sync.Mutex
+	slots map[int64]int
+}
+
+func NewMemoryInvalidRequestCounter() *MemoryInvalidRequestCounter {
+	return &MemoryInvalidRequestCounter{slots: make(map[int64]int)}
+}
+
+func (m *MemoryInvalidRequestCounter) Add(ctx context.Context, window time.Duration) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.slots[time.Now().Unix()]++
+	return m.count(window), nil
+}
+
+func (m *MemoryInvalidRequestCounter) Count(ctx context.Context, window time.Duration) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.count(window), nil
+}
+
+func (m *MemoryInvalidRequestCounter) count(window time.Duration) int {
+	oldest := time.Now().Add(-window).Unix()
+	count := 0
+	for slot, n := range m.slots {
+		if slot <= oldest {
+			delete(m.slots, slot)
+			continue
+		}
+		count += n
+	}
+	return count
+}
+
+// RedisInvalidRequestCounter counts invalid responses in a sorted set so the
+// count can be shared by every process behind the same IP.
+type RedisInvalidRequestCounter struct {
+	rdb *redis.Client
+	key string
+}
+
+func NewRedisInvalidRequestCounter(rdb *redis.Client, key string) *RedisInvalidRequestCounter {
+	if key == "" {
+		key = "wumpgo:invalid_requests"
+	}
+	return &RedisInvalidRequestCounter{rdb: rdb, key: key}
+}
+
+func (r *RedisInvalidRequestCounter) Add(ctx context.Context, window time.Duration) (int, error) {
+	now := time.Now()
+	member := strconv.FormatInt(now.UnixNano(), 10) + "-" + strconv.FormatInt(rand.Int63(), 36)
+
+	pipe := r.rdb.TxPipeline()
+	pipe.ZRemRangeByScore(ctx, r.key, "-inf", strconv.FormatInt(now.Add(-window).UnixNano(), 10))
+	pipe.ZAdd(ctx, r.key, redis.Z{Score: float64(now.UnixNano()), Member: member})
+	card := pipe.ZCard(ctx, r.key)
+	pipe.Expire(ctx, r.key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	return int(card.Val()), nil
+}
+
+func (r *RedisInvalidRequestCounter) Count(ctx context.Context, window time.Duration) (int, error) {
+	min := strconv.FormatInt(time.Now().Add(-window).UnixNano(), 10)
+	n, err := r.rdb.ZCount(ctx, r.key, "("+min, "+inf").Result()
+	return int(n), err
+}
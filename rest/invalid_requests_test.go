@@ -0,0 +1,68 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+func TestInvalidRequestProtection(t *testing.T) {
+	t.Parallel()
+
+	counters := map[string]func(t *testing.T) InvalidRequestCounter{
+		"memory": func(t *testing.T) InvalidRequestCounter {
+			return NewMemoryInvalidRequestCounter()
+		},
+		"redis": func(t *testing.T) InvalidRequestCounter {
+			mr := miniredis.RunT(t)
+			return NewRedisInvalidRequestCounter(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "")
+		},
+	}
+
+	for name, newCounter := range counters {
+		newCounter := newCounter
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var hits atomic.Int64
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits.Inc()
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"message": "Missing Access", "code": 50001}`))
+			}))
+			t.Cleanup(srv.Close)
+
+			var warnings []int
+			c := New(
+				WithRateLimiter(nil),
+				WithInvalidRequestProtection(InvalidRequestConf{
+					Counter: newCounter(t),
+					Limit:   10,
+					WarnAt:  3,
+					BreakAt: 5,
+					OnWarn: func(count, limit int) {
+						require.Equal(t, 10, limit)
+						warnings = append(warnings, count)
+					},
+				}),
+			).(*Client)
+
+			for i := 0; i < 5; i++ {
+				err := NewRequest().Method(http.MethodGet).Path(srv.URL + "/api/v10/users/@me").Send(c)
+				require.True(t, IsMissingPermissions(err))
+			}
+
+			err := NewRequest().Method(http.MethodGet).Path(srv.URL + "/api/v10/users/@me").Send(c)
+			var limitErr *ErrorInvalidRequestLimit
+			require.ErrorAs(t, err, &limitErr)
+			require.Equal(t, 5, limitErr.Count)
+			require.EqualValues(t, 5, hits.Load())
+			require.Equal(t, []int{3, 5}, warnings)
+		})
+	}
+}
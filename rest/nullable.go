@@ -0,0 +1,27 @@
+package rest
+
+import "encoding/json"
+
+// Nullable is a parameter that can be cleared by sending null. A nil
+// *Nullable is omitted and leaves the field unchanged.
+type Nullable[T any] struct {
+	value T
+	null  bool
+}
+
+// NewNullable returns a Nullable that sets the field to v.
+func NewNullable[T any](v T) *Nullable[T] {
+	return &Nullable[T]{value: v}
+}
+
+// Null returns a Nullable that clears the field.
+func Null[T any]() *Nullable[T] {
+	return &Nullable[T]{null: true}
+}
+
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if n.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.value)
+}
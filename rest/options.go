@@ -46,3 +46,33 @@ func WithUserAgent(a *UserAgent) RestOption {
 		c.userAgent = a
 	}
 }
+
+// WithInvalidRequestProtection configures how invalid (401, 403 and 429)
+// responses are tracked to avoid being banned by Discord.
+func WithInvalidRequestProtection(conf InvalidRequestConf) RestOption {
+	return func(c *Client) {
+		c.invalidRequests = newInvalidRequestGuard(conf)
+	}
+}
+
+// WithoutInvalidRequestProtection disables invalid response tracking.
+func WithoutInvalidRequestProtection() RestOption {
+	return func(c *Client) {
+		c.invalidRequests = nil
+	}
+}
+
+// WithRetryPolicy sets how transient failures are retried, DefaultRetryPolicy
+// is used otherwise.
+func WithRetryPolicy(p RetryPolicy) RestOption {
+	return func(c *Client) {
+		c.retryPolicy = &p
+	}
+}
+
+// WithoutRetries disables retrying transient failures.
+func WithoutRetries() RestOption {
+	return func(c *Client) {
+		c.retryPolicy = nil
+	}
+}
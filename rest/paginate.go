@@ -0,0 +1,393 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+
+	"wumpgo.dev/wumpgo/objects"
+)
+
+// Direction is the order a Paginator walks in.
+type Direction int
+
+const (
+	// Backward walks from newest to oldest using the before parameter.
+	Backward Direction = iota
+	// Forward walks from oldest to newest using the after parameter.
+	Forward
+)
+
+// ErrUnsupportedDirection is returned when an endpoint can't be walked in
+// the requested Direction.
+var ErrUnsupportedDirection = errors.New("rest: endpoint can't be paginated in this direction")
+
+// ErrNoCursor is returned when no item of a page has an ID to continue the
+// walk from, e.g. members without a user.
+var ErrNoCursor = errors.New("rest: page has no item to continue from")
+
+// PageFunc fetches up to limit items following cursor in dir, in the order
+// they should be walked. A zero cursor starts at the newest or oldest item.
+// more is false once there is nothing left to fetch.
+type PageFunc[T any] func(ctx context.Context, dir Direction, cursor objects.Snowflake, limit int) (page []T, more bool, err error)
+
+// Paginator walks a list endpoint one page at a time.
+//
+//	p := rest.PaginateChannelMessages(client, channel).Limit(500)
+//	for p.Next(ctx) {
+//		msg := p.Value()
+//	}
+//	if err := p.Err(); err != nil {
+//		...
+//	}
+type Paginator[T any] struct {
+	fetch    PageFunc[T]
+	id       func(T) objects.Snowflake
+	dir      Direction
+	cursor   objects.Snowflake
+	pageSize int
+	limit    int
+	until    func(T) bool
+
+	page  []T
+	value T
+	count int
+	last  bool
+	stuck bool
+	done  bool
+	err   error
+}
+
+// Paginate returns a Paginator over fetch. id returns the cursor of an item
+// and pageSize is the largest page the endpoint accepts.
+func Paginate[T any](fetch PageFunc[T], id func(T) objects.Snowflake, pageSize int) *Paginator[T] {
+	return &Paginator[T]{
+		fetch:    fetch,
+		id:       id,
+		pageSize: pageSize,
+	}
+}
+
+// Direction sets the order of the walk, Backward by default.
+func (p *Paginator[T]) Direction(dir Direction) *Paginator[T] {
+	p.dir = dir
+	return p
+}
+
+// Start begins the walk before or after the given ID, excluding it.
+func (p *Paginator[T]) Start(id objects.Snowflake) *Paginator[T] {
+	p.cursor = id
+	return p
+}
+
+// Limit stops the walk after n items, n <= 0 means no limit.
+func (p *Paginator[T]) Limit(n int) *Paginator[T] {
+	p.limit = n
+	return p
+}
+
+// PageSize sets how many items are requested at once, capped at the
+// endpoint's maximum.
+func (p *Paginator[T]) PageSize(n int) *Paginator[T] {
+	if n > 0 && n < p.pageSize {
+		p.pageSize = n
+	}
+	return p
+}
+
+// Until stops the walk at the first item for which stop returns true. That
+// item is not returned.
+func (p *Paginator[T]) Until(stop func(T) bool) *Paginator[T] {
+	p.until = stop
+	return p
+}
+
+// Next advances to the next item, fetching a new page if needed. It returns
+// false when the walk is over or failed, see Err.
+func (p *Paginator[T]) Next(ctx context.Context) bool {
+	if p.done || (p.limit > 0 && p.count >= p.limit) {
+		p.done = true
+		return false
+	}
+
+	if len(p.page) == 0 {
+		if p.last || !p.fetchPage(ctx) {
+			p.done = true
+			return false
+		}
+	}
+
+	p.value, p.page = p.page[0], p.page[1:]
+	if p.until != nil && p.until(p.value) {
+		p.done = true
+		return false
+	}
+
+	p.count++
+	return true
+}
+
+func (p *Paginator[T]) fetchPage(ctx context.Context) bool {
+	if err := ctx.Err(); err != nil {
+		p.err = err
+		return false
+	}
+	if p.stuck {
+		p.err = ErrNoCursor
+		return false
+	}
+
+	size := p.pageSize
+	if p.limit > 0 && p.limit-p.count < size {
+		size = p.limit - p.count
+	}
+
+	page, more, err := p.fetch(ctx, p.dir, p.cursor, size)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	if len(page) == 0 {
+		return false
+	}
+
+	p.page = page
+	p.last = !more || len(page) < size
+
+	// Items without an ID can't be continued from, the last one that has
+	// one is used instead.
+	p.stuck = true
+	for i := len(page) - 1; i >= 0; i-- {
+		if id := p.id(page[i]); id != 0 {
+			p.cursor = id
+			p.stuck = false
+			break
+		}
+	}
+	return true
+}
+
+// Value returns the current item.
+func (p *Paginator[T]) Value() T {
+	return p.value
+}
+
+// Err returns the error that ended the walk, if any.
+func (p *Paginator[T]) Err() error {
+	return p.err
+}
+
+// Walk calls fn for each item until it returns false or the walk ends.
+func (p *Paginator[T]) Walk(ctx context.Context, fn func(T) bool) error {
+	for p.Next(ctx) {
+		if !fn(p.Value()) {
+			break
+		}
+	}
+	return p.Err()
+}
+
+// All collects the remaining items.
+func (p *Paginator[T]) All(ctx context.Context) ([]T, error) {
+	var items []T
+	for p.Next(ctx) {
+		items = append(items, p.Value())
+	}
+	return items, p.Err()
+}
+
+// maxSnowflake is used to start walking Backward from the newest item, as
+// some endpoints start from the oldest one when before is omitted.
+const maxSnowflake = objects.Snowflake(math.MaxInt64)
+
+// cursors returns the before and after parameters for a page. A zero cursor
+// is replaced so the parameter isn't omitted, since endpoints disagree on
+// where they start without one.
+func cursors(dir Direction, cursor objects.Snowflake) (before, after objects.Snowflake) {
+	if dir == Forward {
+		if cursor == 0 {
+			cursor = 1
+		}
+		return 0, cursor
+	}
+	if cursor == 0 {
+		cursor = maxSnowflake
+	}
+	return cursor, 0
+}
+
+// ordered sorts page by id in the order of dir. Endpoints don't agree on
+// the order they return items in.
+func ordered[T any](page []T, id func(T) objects.Snowflake, dir Direction) []T {
+	sort.Slice(page, func(i, j int) bool {
+		if dir == Forward {
+			return id(page[i]) < id(page[j])
+		}
+		return id(page[i]) > id(page[j])
+	})
+	return page
+}
+
+func messageID(m *objects.Message) objects.Snowflake { return m.ID }
+func userID(u *objects.User) objects.Snowflake       { return u.ID }
+func guildID(g *objects.Guild) objects.Snowflake     { return g.ID }
+func channelID(c *objects.Channel) objects.Snowflake { return c.ID }
+
+func memberID(m *objects.GuildMember) objects.Snowflake {
+	if m.User == nil {
+		return 0
+	}
+	return m.User.ID
+}
+
+func banID(b *objects.Ban) objects.Snowflake {
+	if b.User == nil {
+		return 0
+	}
+	return b.User.ID
+}
+
+func auditLogEntryID(e *objects.AuditLogEntry) objects.Snowflake { return e.ID }
+
+func scheduledEventUserID(u *objects.GuildScheduledEventUser) objects.Snowflake {
+	if u.User == nil {
+		return 0
+	}
+	return u.User.ID
+}
+
+// PaginateChannelMessages walks the messages of a channel.
+func PaginateChannelMessages(c RESTClient, channel objects.Snowflake) *Paginator[*objects.Message] {
+	return Paginate(func(ctx context.Context, dir Direction, cursor objects.Snowflake, limit int) ([]*objects.Message, bool, error) {
+		before, after := cursors(dir, cursor)
+		page, err := c.GetChannelMessages(ctx, channel, &GetChannelMessagesParams{Before: before, After: after, Limit: limit})
+		return ordered(page, messageID, dir), true, err
+	}, messageID, 100)
+}
+
+// PaginateGuildMembers walks the members of a guild. It only walks Forward.
+func PaginateGuildMembers(c RESTClient, guild objects.Snowflake) *Paginator[*objects.GuildMember] {
+	return Paginate(func(ctx context.Context, dir Direction, cursor objects.Snowflake, limit int) ([]*objects.GuildMember, bool, error) {
+		if dir != Forward {
+			return nil, false, ErrUnsupportedDirection
+		}
+		page, err := c.ListGuildMembers(ctx, guild, &ListGuildMembersParams{After: cursor, Limit: limit})
+		return ordered(page, memberID, dir), true, err
+	}, memberID, 1000).Direction(Forward)
+}
+
+// PaginateGuildBans walks the bans of a guild.
+func PaginateGuildBans(c RESTClient, guild objects.Snowflake) *Paginator[*objects.Ban] {
+	return Paginate(func(ctx context.Context, dir Direction, cursor objects.Snowflake, limit int) ([]*objects.Ban, bool, error) {
+		before, after := cursors(dir, cursor)
+		page, err := c.GetGuildBans(ctx, guild, &GetGuildBansParams{Before: before, After: after, Limit: limit})
+		return ordered(page, banID, dir), true, err
+	}, banID, 1000)
+}
+
+// PaginateAuditLog walks the audit log entries of a guild. params filters
+// the entries, its cursors and limit are ignored.
+func PaginateAuditLog(c RESTClient, guild objects.Snowflake, params *GetAuditLogParams) *Paginator[*objects.AuditLogEntry] {
+	var filter GetAuditLogParams
+	if params != nil {
+		filter = *params
+	}
+	return Paginate(func(ctx context.Context, dir Direction, cursor objects.Snowflake, limit int) ([]*objects.AuditLogEntry, bool, error) {
+		p := filter
+		p.Before, p.After = cursors(dir, cursor)
+		p.Limit = limit
+		log, err := c.GetAuditLogs(ctx, guild, &p)
+		if err != nil {
+			return nil, false, err
+		}
+		return ordered(log.AuditLogEntries, auditLogEntryID, dir), true, nil
+	}, auditLogEntryID, 100)
+}
+
+// PaginateReactions walks the users that reacted with emoji to a message.
+// It only walks Forward.
+func PaginateReactions(c RESTClient, channel, message objects.Snowflake, emoji interface{}) *Paginator[*objects.User] {
+	return Paginate(func(ctx context.Context, dir Direction, cursor objects.Snowflake, limit int) ([]*objects.User, bool, error) {
+		if dir != Forward {
+			return nil, false, ErrUnsupportedDirection
+		}
+		page, err := c.GetReactions(ctx, channel, message, emoji, &GetReactionsParams{After: cursor, Limit: limit})
+		return ordered(page, userID, dir), true, err
+	}, userID, 100).Direction(Forward)
+}
+
+// PaginateCurrentUserGuilds walks the guilds the current user is in.
+func PaginateCurrentUserGuilds(c RESTClient) *Paginator[*objects.Guild] {
+	return Paginate(func(ctx context.Context, dir Direction, cursor objects.Snowflake, limit int) ([]*objects.Guild, bool, error) {
+		before, after := cursors(dir, cursor)
+		page, err := c.GetCurrentUserGuilds(ctx, &CurrentUserGuildsParams{Before: before, After: after, Limit: limit})
+		return ordered(page, guildID, dir), true, err
+	}, guildID, 200)
+}
+
+// PaginateGuildScheduledEventUsers walks the users subscribed to a scheduled
+// event.
+func PaginateGuildScheduledEventUsers(c RESTClient, guild, event objects.Snowflake) *Paginator[*objects.GuildScheduledEventUser] {
+	return Paginate(func(ctx context.Context, dir Direction, cursor objects.Snowflake, limit int) ([]*objects.GuildScheduledEventUser, bool, error) {
+		params := &GetGuildScheduledEventUsersParams{Limit: limit}
+		before, after := cursors(dir, cursor)
+		if dir == Forward {
+			params.After = &after
+		} else {
+			params.Before = &before
+		}
+		page, err := c.GetGuildScheduledEventUsers(ctx, guild, event, params)
+		return ordered(page, scheduledEventUserID, dir), true, err
+	}, scheduledEventUserID, 100)
+}
+
+type listThreadsFunc func(ctx context.Context, channel objects.Snowflake, params ...*ListThreadsParams) (*ListThreadsResponse, error)
+
+// paginateArchivedThreads walks archived threads from the most recently
+// archived. The endpoints page by archive timestamp rather than ID, so the
+// cursor is kept here and Start has no effect.
+func paginateArchivedThreads(list listThreadsFunc, channel objects.Snowflake) *Paginator[*objects.Channel] {
+	var before objects.Time
+	return Paginate(func(ctx context.Context, dir Direction, _ objects.Snowflake, limit int) ([]*objects.Channel, bool, error) {
+		if dir != Backward {
+			return nil, false, ErrUnsupportedDirection
+		}
+		resp, err := list(ctx, channel, &ListThreadsParams{Before: before, Limit: limit})
+		if err != nil {
+			return nil, false, err
+		}
+		if n := len(resp.Threads); n > 0 && resp.Threads[n-1].ThreadMetadata != nil {
+			before = resp.Threads[n-1].ThreadMetadata.ArchivedTimestamp
+		}
+		return resp.Threads, resp.HasMore, nil
+	}, channelID, 100)
+}
+
+// PaginatePublicArchivedThreads walks the public archived threads of a
+// channel. It only walks Backward.
+func PaginatePublicArchivedThreads(c RESTClient, channel objects.Snowflake) *Paginator[*objects.Channel] {
+	return paginateArchivedThreads(c.ListPublicArchivedThreads, channel)
+}
+
+// PaginatePrivateArchivedThreads walks the private archived threads of a
+// channel. It only walks Backward.
+func PaginatePrivateArchivedThreads(c RESTClient, channel objects.Snowflake) *Paginator[*objects.Channel] {
+	return paginateArchivedThreads(c.ListPrivateArchivedThreads, channel)
+}
+
+// PaginateJoinedPrivateArchivedThreads walks the private archived threads of
+// a channel that the current user has joined, from the newest thread. It
+// only walks Backward.
+func PaginateJoinedPrivateArchivedThreads(c RESTClient, channel objects.Snowflake) *Paginator[*objects.Channel] {
+	return Paginate(func(ctx context.Context, dir Direction, cursor objects.Snowflake, limit int) ([]*objects.Channel, bool, error) {
+		if dir != Backward {
+			return nil, false, ErrUnsupportedDirection
+		}
+		resp, err := c.ListJoinedPrivateArchivedThreads(ctx, channel, &ListJoinedThreadsParams{Before: cursor, Limit: limit})
+		if err != nil {
+			return nil, false, err
+		}
+		return ordered(resp.Threads, channelID, dir), resp.HasMore, nil
+	}, channelID, 100)
+}
@@ -0,0 +1,304 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wumpgo.dev/wumpgo/objects"
+)
+
+func newTestPages(n int, requests *int) PageFunc[objects.Snowflake] {
+	return func(ctx context.Context, dir Direction, cursor objects.Snowflake, limit int) ([]objects.Snowflake, bool, error) {
+		*requests++
+		before, after := cursors(dir, cursor)
+		var page []objects.Snowflake
+		for id := objects.Snowflake(10); id < objects.Snowflake(10+n); id++ {
+			if (before == 0 || id < before) && id > after {
+				page = append(page, id)
+			}
+		}
+		if dir == Backward && len(page) > limit {
+			page = page[len(page)-limit:]
+		} else if len(page) > limit {
+			page = page[:limit]
+		}
+		return ordered(page, func(id objects.Snowflake) objects.Snowflake { return id }, dir), true, nil
+	}
+}
+
+func TestPaginator(t *testing.T) {
+	t.Parallel()
+
+	id := func(id objects.Snowflake) objects.Snowflake { return id }
+
+	tt := []struct {
+		name  string
+		setup func(p *Paginator[objects.Snowflake]) *Paginator[objects.Snowflake]
+
+		want     []objects.Snowflake
+		wantReqs int
+	}{
+		{
+			name:     "backward",
+			setup:    func(p *Paginator[objects.Snowflake]) *Paginator[objects.Snowflake] { return p.Limit(4) },
+			want:     []objects.Snowflake{259, 258, 257, 256},
+			wantReqs: 1,
+		},
+		{
+			name: "forward from start",
+			setup: func(p *Paginator[objects.Snowflake]) *Paginator[objects.Snowflake] {
+				return p.Direction(Forward).Start(100).Limit(3)
+			},
+			want:     []objects.Snowflake{101, 102, 103},
+			wantReqs: 1,
+		},
+		{
+			name: "until",
+			setup: func(p *Paginator[objects.Snowflake]) *Paginator[objects.Snowflake] {
+				return p.Direction(Forward).PageSize(2).Until(func(id objects.Snowflake) bool { return id > 14 })
+			},
+			want:     []objects.Snowflake{10, 11, 12, 13, 14},
+			wantReqs: 3,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var reqs int
+			got, err := tc.setup(Paginate(newTestPages(250, &reqs), id, 100)).All(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+			require.Equal(t, tc.wantReqs, reqs)
+		})
+	}
+
+	t.Run("all", func(t *testing.T) {
+		t.Parallel()
+
+		var reqs int
+		got, err := Paginate(newTestPages(250, &reqs), id, 100).All(context.Background())
+		require.NoError(t, err)
+		require.Len(t, got, 250)
+		require.Equal(t, objects.Snowflake(259), got[0])
+		require.Equal(t, objects.Snowflake(10), got[249])
+		require.Equal(t, 3, reqs)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		var reqs int
+		p := Paginate(newTestPages(250, &reqs), id, 100)
+		err := p.Walk(ctx, func(objects.Snowflake) bool {
+			cancel()
+			return true
+		})
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, 1, reqs)
+	})
+}
+
+func TestPaginateChannelMessages(t *testing.T) {
+	t.Parallel()
+
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		before, _ := strconv.ParseUint(r.URL.Query().Get("before"), 10, 64)
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		// Newest first, like Discord.
+		var messages []*objects.Message
+		for id := before - 1; id > 0 && len(messages) < limit && id > 1000-150; id-- {
+			messages = append(messages, &objects.Message{ID: objects.Snowflake(id)})
+		}
+		_ = json.NewEncoder(w).Encode(messages)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New(WithoutRetries()).(*Client)
+	c.httpClient = &rewriteHTTPClient{next: c.httpClient, base: srv.URL}
+
+	p := PaginateChannelMessages(c, 686053040863969305).Start(1001).Limit(120)
+	messages, err := p.All(context.Background())
+	require.NoError(t, err)
+	require.Len(t, messages, 120)
+	require.Equal(t, objects.Snowflake(1000), messages[0].ID)
+	require.Equal(t, objects.Snowflake(881), messages[119].ID)
+	require.Equal(t, []string{"before=1001&limit=100", "before=901&limit=20"}, queries)
+}
+
+func TestPaginator_ZeroIDs(t *testing.T) {
+	t.Parallel()
+
+	id := func(m *objects.GuildMember) objects.Snowflake { return memberID(m) }
+	member := func(id objects.Snowflake) *objects.GuildMember {
+		if id == 0 {
+			return &objects.GuildMember{}
+		}
+		return &objects.GuildMember{User: &objects.User{ID: id}}
+	}
+
+	tt := []struct {
+		name    string
+		pages   [][]objects.Snowflake
+		wantLen int
+		wantErr error
+		wantCur []objects.Snowflake
+	}{
+		{
+			name:    "last item without user",
+			pages:   [][]objects.Snowflake{{1, 2, 0}, {3}},
+			wantLen: 4,
+			wantCur: []objects.Snowflake{0, 2},
+		},
+		{
+			name:    "page without users",
+			pages:   [][]objects.Snowflake{{0, 0, 0}, {3}},
+			wantLen: 3,
+			wantErr: ErrNoCursor,
+			wantCur: []objects.Snowflake{0},
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var cursors []objects.Snowflake
+			fetch := func(_ context.Context, _ Direction, cursor objects.Snowflake, limit int) ([]*objects.GuildMember, bool, error) {
+				cursors = append(cursors, cursor)
+				if len(cursors) > len(tc.pages) {
+					return nil, false, nil
+				}
+				var page []*objects.GuildMember
+				for _, id := range tc.pages[len(cursors)-1] {
+					page = append(page, member(id))
+				}
+				return page, len(cursors) < len(tc.pages), nil
+			}
+
+			got, err := Paginate(fetch, id, 3).Direction(Forward).All(context.Background())
+			require.ErrorIs(t, err, tc.wantErr)
+			require.Len(t, got, tc.wantLen)
+			require.Equal(t, tc.wantCur, cursors)
+		})
+	}
+}
+
+func TestPaginateArchivedThreads(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	thread := func(id int, archived time.Time) *objects.Channel {
+		return &objects.Channel{
+			ID:             objects.Snowflake(id),
+			ThreadMetadata: &objects.ThreadMetadata{ArchivedTimestamp: objects.Time{Time: archived}},
+		}
+	}
+
+	tt := []struct {
+		name     string
+		paginate func(c RESTClient) *Paginator[*objects.Channel]
+		path     string
+		pages    map[string]ListThreadsResponse
+		want     []objects.Snowflake
+		wantReqs []string
+	}{
+		{
+			name:     "public by archive timestamp",
+			paginate: func(c RESTClient) *Paginator[*objects.Channel] { return PaginatePublicArchivedThreads(c, 1) },
+			path:     "/api/v10/channels/1/threads/archived/public",
+			pages: map[string]ListThreadsResponse{
+				"limit=2": {
+					Threads: []*objects.Channel{thread(5, day.Add(3*time.Hour)), thread(9, day.Add(2*time.Hour))},
+					HasMore: true,
+				},
+				"before=2023-01-01T02%3A00%3A00Z&limit=2": {
+					Threads: []*objects.Channel{thread(4, day.Add(time.Hour))},
+				},
+			},
+			want:     []objects.Snowflake{5, 9, 4},
+			wantReqs: []string{"limit=2", "before=2023-01-01T02%3A00%3A00Z&limit=2"},
+		},
+		{
+			name:     "joined by thread ID",
+			paginate: func(c RESTClient) *Paginator[*objects.Channel] { return PaginateJoinedPrivateArchivedThreads(c, 1) },
+			path:     "/api/v10/channels/1/users/@me/threads/archived/private",
+			pages: map[string]ListThreadsResponse{
+				"limit=2": {
+					Threads: []*objects.Channel{thread(8, day), thread(9, day)},
+					HasMore: true,
+				},
+				"before=8&limit=2": {
+					Threads: []*objects.Channel{thread(7, day), thread(6, day)},
+					HasMore: false,
+				},
+			},
+			want:     []objects.Snowflake{9, 8, 7, 6},
+			wantReqs: []string{"limit=2", "before=8&limit=2"},
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var lock sync.Mutex
+			var reqs []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				lock.Lock()
+				reqs = append(reqs, r.URL.RawQuery)
+				lock.Unlock()
+
+				assert.Equal(t, tc.path, r.URL.Path)
+				page, ok := tc.pages[r.URL.RawQuery]
+				if !assert.True(t, ok, "unexpected query %s", r.URL.RawQuery) {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				_ = json.NewEncoder(w).Encode(page)
+			}))
+			t.Cleanup(srv.Close)
+
+			c := New(WithoutRetries()).(*Client)
+			c.httpClient = &rewriteHTTPClient{next: c.httpClient, base: srv.URL}
+
+			threads, err := tc.paginate(c).PageSize(2).All(context.Background())
+			require.NoError(t, err)
+
+			var ids []objects.Snowflake
+			for _, th := range threads {
+				ids = append(ids, th.ID)
+			}
+			require.Equal(t, tc.want, ids)
+			require.Equal(t, tc.wantReqs, reqs)
+		})
+	}
+}
+
+// rewriteHTTPClient sends every request to base instead of Discord.
+type rewriteHTTPClient struct {
+	next HTTPClient
+	base string
+}
+
+func (c *rewriteHTTPClient) Request(req *request) (*DiscordResponse, error) {
+	r := *req
+	r.path = c.base + "/api/v10" + req.path[len(BaseURL):]
+	return c.next.Request(&r)
+}
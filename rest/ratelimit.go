@@ -1,21 +1,98 @@
 package rest
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"regexp"
 	"strings"
+	"time"
 )
 
 type Ratelimiter interface {
 	Request(httpClient HTTPClient, req *request) (*DiscordResponse, error)
 }
 
-var userIdRe = regexp.MustCompile(`\d{16,19}`)
-
 var majorParams = [...]string{"channels", "guilds", "webhooks"}
 
 var ErrMaxRetriesExceeded = errors.New("max retries exceeded")
 
+// ErrorRateLimited is returned instead of waiting when a request would be
+// delayed longer than its context allows, see WithMaxWait.
+type ErrorRateLimited struct {
+	// RetryAfter is how long the request would have waited.
+	RetryAfter time.Duration
+	Global     bool
+}
+
+func (e *ErrorRateLimited) Error() string {
+	scope := "bucket"
+	if e.Global {
+		scope = "global"
+	}
+	return fmt.Sprintf("rate limited (%s), would wait %s", scope, e.RetryAfter)
+}
+
+type maxWaitKey struct{}
+
+// WithMaxWait returns a context making requests fail with *ErrorRateLimited
+// instead of waiting longer than d for a rate limit.
+func WithMaxWait(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, maxWaitKey{}, d)
+}
+
+// FailFast returns a context making requests fail with *ErrorRateLimited
+// instead of waiting for a rate limit at all.
+func FailFast(ctx context.Context) context.Context {
+	return WithMaxWait(ctx, 0)
+}
+
+// maxWait returns how long a request made with ctx may wait for a rate
+// limit, taking the deadline of ctx into account. It returns a negative
+// duration if there is no limit.
+func maxWait(ctx context.Context) time.Duration {
+	if ctx == nil {
+		return -1
+	}
+
+	max := time.Duration(-1)
+	if d, ok := ctx.Value(maxWaitKey{}).(time.Duration); ok {
+		max = d
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if until := time.Until(deadline); max < 0 || until < max {
+			max = until
+		}
+		if max < 0 {
+			max = 0
+		}
+	}
+
+	return max
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	if ctx == nil {
+		time.Sleep(d)
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func isBucketedParam(comp string) bool {
 	for _, param := range majorParams {
 		if comp == param {
@@ -25,21 +102,42 @@ func isBucketedParam(comp string) bool {
 	return false
 }
 
-func getBucketID(urlStr string) string {
-	comps := strings.Split(urlStr, "/")
-	bucketComps := make([]string, 0)
-	for i, comp := range comps[5:] {
-		if comp == "reactions" {
-			bucketComps = append(bucketComps, comp)
-			break
-		}
+var snowRe = regexp.MustCompile(`\d{17,19}`)
+
+// requestRoute identifies the rate limit bucket of a request before its hash is
+// known. It is shared by every Ratelimiter so learned mappings agree.
+func requestRoute(method, path string) string {
+	return method + ":" + parseRoute(path)
+}
 
-		if userIdRe.MatchString(comp) && !isBucketedParam(comps[5:][i]) {
-			bucketComps = append(bucketComps, "id")
-		} else {
-			bucketComps = append(bucketComps, comp)
+func parseRoute(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	splitPath := strings.Split(path, "/")
+	includeNext := true
+	routeKeyParts := []string{}
+	for _, c := range splitPath[3:] {
+		isSnowflake := snowRe.MatchString(c)
+		if isSnowflake && includeNext {
+			routeKeyParts = append(routeKeyParts, c)
+			includeNext = false
+		} else if !isSnowflake {
+			routeKeyParts = append(routeKeyParts, c)
+			if c == "channels" || c == "guilds" || c == "webhooks" {
+				includeNext = true
+			}
 		}
 	}
+	return strings.Join(routeKeyParts, ":")
+}
 
-	return strings.Join(bucketComps, ":")
+// majorParameter returns the channel, guild or webhook ID in path, if any.
+func majorParameter(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	comps := strings.Split(path, "/")
+	for i := 0; i < len(comps)-1; i++ {
+		if isBucketedParam(comps[i]) && snowRe.MatchString(comps[i+1]) {
+			return comps[i+1]
+		}
+	}
+	return ""
 }
@@ -1,13 +1,12 @@
 package rest
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
-	"math"
 	"net/http"
 	"net/url"
-	"regexp"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 
@@ -15,88 +14,302 @@ import (
 	"golang.org/x/time/rate"
 )
 
-// NewLeakyBucketRatelimiter creates a new LeakyBucketRatelimiter based on rate.Limiter
+var _ Ratelimiter = (*LeakyBucketRatelimiter)(nil)
+
+const (
+	// DefaultGlobalLimit is the number of requests per second a bot may
+	// make across all routes.
+	DefaultGlobalLimit = 50
+	// DefaultMaxRetries is the number of times a rate limited request is
+	// retried before giving up.
+	DefaultMaxRetries = 3
+)
+
+// NewLeakyBucketRatelimiter creates a new LeakyBucketRatelimiter with the
+// default global limit and retry count.
 func NewLeakyBucketRatelimiter() *LeakyBucketRatelimiter {
-	return &LeakyBucketRatelimiter{
-		buckets:  make(map[string]*rate.Limiter),
-		routeMap: make(map[string]string),
-	}
+	return NewMemoryRatelimiter(&MemoryConf{})
 }
 
+// LeakyBucketRatelimiter tracks Discord's rate limit buckets in memory.
+//
+// Each bucket is keyed by the X-RateLimit-Bucket hash and the major
+// parameter of the route, and requests wait for the bucket to reset once
+// X-RateLimit-Remaining reaches zero. All requests made with the bot token
+// additionally share the global limit. A 429 response is retried after the
+// delay Discord asks for, up to MaxRetries times.
+//
+// Waits end early when the request's context is done, and fail with
+// *ErrorRateLimited if they would exceed WithMaxWait or the deadline.
 type LeakyBucketRatelimiter struct {
 	sync.RWMutex
-	buckets  map[string]*rate.Limiter
+	buckets  map[string]*Bucket
 	routeMap map[string]string
+
+	global      *rate.Limiter
+	globalLock  sync.Mutex
+	globalReset time.Time
+
+	maxRetries int
+}
+
+// Bucket is the last known state of a single rate limit bucket.
+type Bucket struct {
+	mu        sync.Mutex
+	limit     int
+	remaining int
+	reset     time.Time
+	// window is the longest reset delay seen, used to guess when the
+	// window after the current one ends.
+	window time.Duration
+}
+
+// Limit returns the number of requests the bucket allows per window.
+func (b *Bucket) Limit() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.limit
+}
+
+// Remaining returns the number of requests left in the current window.
+func (b *Bucket) Remaining() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if time.Now().After(b.reset) {
+		return b.limit
+	}
+	return b.remaining
+}
+
+// Reset returns when the current window ends.
+func (b *Bucket) Reset() time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.reset
+}
+
+// take claims a request from the bucket. If the window is exhausted nothing
+// is claimed and take returns how long until the window resets, when the
+// caller has to try again. Claiming ahead from the next window would let
+// every caller after the first one through without waiting.
+func (b *Bucket) take(now time.Time) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !now.Before(b.reset) {
+		b.remaining = b.limit
+		b.reset = now.Add(b.window)
+	}
+
+	if b.remaining > 0 {
+		b.remaining--
+		return 0, true
+	}
+
+	return b.reset.Sub(now), false
+}
+
+// update records the rate limit headers of a response. Responses to requests
+// sent earlier in the same window don't count the slots claimed since, so
+// they never raise remaining for a reset less than half a window later.
+func (b *Bucket) update(limit, remaining int, resetAfter time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	reset := time.Now().Add(resetAfter)
+	if remaining > b.remaining && reset.Before(b.reset.Add(b.window/2)) {
+		remaining = b.remaining
+	}
+	b.limit = limit
+	b.remaining = remaining
+	b.reset = reset
+	if resetAfter > b.window {
+		b.window = resetAfter
+	}
+}
+
+func (b *Bucket) exhaust(reset time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.remaining = 0
+	if reset.After(b.reset) {
+		b.reset = reset
+	}
+}
+
+// rateLimitResponse is the body of a 429 response.
+type rateLimitResponse struct {
+	Message    string  `json:"message"`
+	RetryAfter float64 `json:"retry_after"`
+	Global     bool    `json:"global"`
 }
 
 func (c *LeakyBucketRatelimiter) Request(httpClient HTTPClient, req *request) (*DiscordResponse, error) {
-	url, err := url.Parse(req.path)
+	u, err := url.Parse(req.path)
 	if err != nil {
 		return nil, err
 	}
-	bucket, err := c.GetBucket(url.Path)
-	if err == nil {
-		zerolog.Ctx(req.ctx).
-			Debug().
-			Interface("bucket", bucket).
-			Int("burst", bucket.Burst()).
-			Float64("limit", float64(bucket.Limit())).
-			Float64("tokens", bucket.Tokens()).
-			Msgf("Processing ratelimit for %s", url.Path)
-		res := bucket.Reserve()
-		if !res.OK() {
-			return nil, ErrMaxRetriesExceeded
+	routeKey := requestRoute(req.method, u.Path)
+	major := majorParameter(u.Path)
+
+	for retries := 0; ; retries++ {
+		if err := c.reserve(req.ctx, routeKey, !req.omitAuth, u.Path); err != nil {
+			return nil, err
+		}
+
+		resp, err := httpClient.Request(req)
+		if err != nil {
+			return nil, err
+		}
+
+		c.updateFromResponse(resp.Header, routeKey, major)
+
+		if resp.StatusCode != http.StatusTooManyRequests {
+			return resp, nil
+		}
+
+		if retries >= c.maxRetries {
+			return resp, ErrMaxRetriesExceeded
 		}
+
+		retryAfter, tracked := c.handleTooManyRequests(resp, routeKey)
 		zerolog.Ctx(req.ctx).Debug().
-			Dur("delay", res.Delay()).
-			Str("path", url.Path).
-			Msg("Ratelimited request")
-		time.Sleep(res.Delay())
+			Dur("retry_after", retryAfter).
+			Str("scope", resp.Header.Get("X-RateLimit-Scope")).
+			Str("path", u.Path).
+			Int("retry", retries+1).
+			Msg("Received 429, retrying")
+		if !tracked {
+			if max := maxWait(req.ctx); max >= 0 && retryAfter > max {
+				return nil, &ErrorRateLimited{RetryAfter: retryAfter}
+			}
+			if err := sleep(req.ctx, retryAfter); err != nil {
+				return nil, err
+			}
+		}
 	}
+}
 
-	resp, err := httpClient.Request(req)
-	if err != nil {
-		return nil, err
+// reserve waits until a request on routeKey can be sent. Waits for an
+// exhausted bucket are retried once the bucket resets, as other requests
+// may claim the new window first.
+func (c *LeakyBucketRatelimiter) reserve(ctx context.Context, routeKey string, global bool, path string) error {
+	max := maxWait(ctx)
+	for {
+		delay, claimed, err := c.wait(routeKey, global, max)
+		if err != nil {
+			return err
+		}
+		if delay > 0 {
+			zerolog.Ctx(ctx).Debug().
+				Dur("delay", delay).
+				Str("path", path).
+				Msg("Ratelimited request")
+			if err := sleep(ctx, delay); err != nil {
+				return err
+			}
+			if max >= 0 {
+				max -= delay
+				if max < 0 {
+					max = 0
+				}
+			}
+		}
+		if claimed {
+			return nil
+		}
 	}
+}
 
-	c.updateFromResponse(resp.Header, url.Path)
+// wait returns how long a request on routeKey has to wait before it can be
+// sent. If claimed is true a slot in its bucket and the global limit was
+// claimed, otherwise the bucket is exhausted and the caller has to call wait
+// again after the delay. If the wait would be longer than max nothing is
+// claimed and *ErrorRateLimited is returned, a negative max never fails.
+func (c *LeakyBucketRatelimiter) wait(routeKey string, global bool, max time.Duration) (delay time.Duration, claimed bool, err error) {
+	now := time.Now()
 
-	return resp, nil
-}
+	b, _ := c.GetBucket(routeKey)
 
-func (c *LeakyBucketRatelimiter) bucketExists(name string) bool {
-	c.RLock()
-	defer c.RUnlock()
-	_, ok := c.buckets[name]
-	return ok
-}
+	if global {
+		c.globalLock.Lock()
+		if now.Before(c.globalReset) {
+			delay = c.globalReset.Sub(now)
+		}
+		c.globalLock.Unlock()
+		if max >= 0 && delay > max {
+			return 0, false, &ErrorRateLimited{RetryAfter: delay, Global: true}
+		}
+	}
 
-func (c *LeakyBucketRatelimiter) mappingExists(name string) bool {
-	c.RLock()
-	defer c.RUnlock()
-	_, ok := c.routeMap[name]
-	return ok
-}
+	if b != nil {
+		d, ok := b.take(now)
+		if !ok {
+			if max >= 0 && d > max {
+				return 0, false, &ErrorRateLimited{RetryAfter: d}
+			}
+			if d > delay {
+				delay = d
+			}
+			return delay, false, nil
+		}
+	}
 
-func (c *LeakyBucketRatelimiter) addBucket(name string, r float64, count float64) {
-	c.Lock()
-	defer c.Unlock()
-	c.buckets[name] = rate.NewLimiter(rate.Every(time.Second*time.Duration(r)), int(count))
-	// Reserve a ticket since we JUST made a request
-	c.buckets[name].ReserveN(time.Now(), int(count))
+	if !global {
+		return delay, true, nil
+	}
+
+	r := c.global.ReserveN(now.Add(delay), 1)
+	if d := r.DelayFrom(now); d > delay {
+		if max >= 0 && d > max {
+			r.CancelAt(now)
+			return 0, false, &ErrorRateLimited{RetryAfter: d, Global: true}
+		}
+		delay = d
+	}
+
+	return delay, true, nil
 }
 
-func (c *LeakyBucketRatelimiter) addMapping(routeKey, bucket string) {
-	c.Lock()
-	defer c.Unlock()
-	c.routeMap[routeKey] = bucket
+// handleTooManyRequests records a 429 against the scope it applies to and
+// returns how long Discord asked us to wait. tracked is false if the delay
+// could not be recorded and the caller has to wait itself.
+func (c *LeakyBucketRatelimiter) handleTooManyRequests(resp *DiscordResponse, routeKey string) (retryAfter time.Duration, tracked bool) {
+	var body rateLimitResponse
+	_ = json.Unmarshal(resp.Body, &body)
+
+	retryAfter = time.Duration(body.RetryAfter * float64(time.Second))
+	if retryAfter <= 0 {
+		if secs, err := strconv.ParseFloat(resp.Header.Get("Retry-After"), 64); err == nil {
+			retryAfter = time.Duration(secs * float64(time.Second))
+		}
+	}
+	reset := time.Now().Add(retryAfter)
+
+	if body.Global || resp.Header.Get("X-RateLimit-Global") == "true" || resp.Header.Get("X-RateLimit-Scope") == "global" {
+		c.globalLock.Lock()
+		if reset.After(c.globalReset) {
+			c.globalReset = reset
+		}
+		c.globalLock.Unlock()
+		return retryAfter, true
+	}
+
+	// Both user and shared limits are tied to the bucket, shared limits
+	// just don't count against the bot.
+	b, err := c.GetBucket(routeKey)
+	if err != nil {
+		return retryAfter, false
+	}
+	b.exhaust(reset)
+
+	return retryAfter, true
 }
 
-func (c *LeakyBucketRatelimiter) GetBucket(path string) (*rate.Limiter, error) {
+// GetBucket returns the bucket for the given route key.
+func (c *LeakyBucketRatelimiter) GetBucket(routeKey string) (*Bucket, error) {
 	c.RLock()
 	defer c.RUnlock()
 
-	routeKey := parseRoute(path)
 	bucketName, ok := c.routeMap[routeKey]
 	if !ok {
 		return nil, errors.New("no bucket mapping found")
@@ -109,53 +322,40 @@ func (c *LeakyBucketRatelimiter) GetBucket(path string) (*rate.Limiter, error) {
 	return nil, errors.New("no bucket found")
 }
 
-func (c *LeakyBucketRatelimiter) updateFromResponse(h http.Header, path string) {
-	bucket := h.Get("X-RateLimit-Bucket")
-	limitHeader := h.Get("X-RateLimit-Limit")
-	resetAfter := h.Get("X-RateLimit-Reset-After")
+func (c *LeakyBucketRatelimiter) updateFromResponse(h http.Header, routeKey, major string) {
+	hash := h.Get("X-RateLimit-Bucket")
+	if hash == "" {
+		return
+	}
 
-	count, err := strconv.ParseInt(limitHeader, 10, 64)
+	limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit"))
 	if err != nil {
 		return
 	}
 
-	reset, err := strconv.ParseFloat(resetAfter, 64)
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
 	if err != nil {
 		return
 	}
 
-	reset = math.Round(reset/0.25) * 0.25
-
-	if !c.bucketExists(bucket) {
-		// Upon first request, limit and resetAfter should be actual values
-		c.addBucket(bucket, reset, float64(count))
+	resetAfter, err := strconv.ParseFloat(h.Get("X-RateLimit-Reset-After"), 64)
+	if err != nil {
+		return
 	}
 
-	routeKey := parseRoute(path)
-
-	if !c.mappingExists(routeKey) {
-		c.addMapping(routeKey, bucket)
+	name := hash
+	if major != "" {
+		name += ":" + major
 	}
-}
 
-var snowRe = regexp.MustCompile(`\d{17,19}`)
-
-func parseRoute(path string) string {
-	path, _, _ = strings.Cut(path, "?")
-	splitPath := strings.Split(path, "/")
-	includeNext := true
-	routeKeyParts := []string{}
-	for _, c := range splitPath[3:] {
-		isSnowflake := snowRe.MatchString(c)
-		if isSnowflake && includeNext {
-			routeKeyParts = append(routeKeyParts, c)
-			includeNext = false
-		} else if !isSnowflake {
-			routeKeyParts = append(routeKeyParts, c)
-			if c == "channels" || c == "guilds" || c == "webhooks" {
-				includeNext = true
-			}
-		}
+	c.Lock()
+	b, ok := c.buckets[name]
+	if !ok {
+		b = &Bucket{}
+		c.buckets[name] = b
 	}
-	return strings.Join(routeKeyParts, ":")
+	c.routeMap[routeKey] = name
+	c.Unlock()
+
+	b.update(limit, remaining, time.Duration(resetAfter*float64(time.Second)))
 }
@@ -1,9 +1,18 @@
 package rest
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
 )
 
 func TestParseRoute(t *testing.T) {
@@ -20,3 +29,260 @@ func TestParseRoute(t *testing.T) {
 		assert.Equal(t, c.want, got)
 	}
 }
+
+type rateLimitServer struct {
+	*httptest.Server
+	hits atomic.Int64
+}
+
+func newRateLimitServer(t *testing.T, handler func(w http.ResponseWriter, hit int64)) *rateLimitServer {
+	s := &rateLimitServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler(w, s.hits.Add(1))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *rateLimitServer) request(path string) (*request, HTTPClient) {
+	req := &request{
+		method: http.MethodGet,
+		path:   s.URL + path,
+		ctx:    context.Background(),
+	}
+	return req, &DefaultHTTPClient{doer: s.Client()}
+}
+
+func writeRateLimited(w http.ResponseWriter, scope string, retryAfter float64) {
+	w.Header().Set("X-RateLimit-Scope", scope)
+	w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter)+1))
+	if scope == "global" {
+		w.Header().Set("X-RateLimit-Global", "true")
+	}
+	w.WriteHeader(http.StatusTooManyRequests)
+	_, _ = fmt.Fprintf(w, `{"message": "You are being rate limited.", "retry_after": %g, "global": %t}`, retryAfter, scope == "global")
+}
+
+// bucketServer enforces a single rate limit bucket like Discord does,
+// answering with a 429 once the bucket is exhausted.
+type bucketServer struct {
+	*rateLimitServer
+	limited atomic.Int64
+}
+
+func newBucketServer(t *testing.T, limit int, window time.Duration) *bucketServer {
+	var (
+		lock      sync.Mutex
+		reset     time.Time
+		remaining int
+	)
+
+	s := &bucketServer{}
+	s.rateLimitServer = newRateLimitServer(t, func(w http.ResponseWriter, _ int64) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		now := time.Now()
+		if !now.Before(reset) {
+			reset = now.Add(window)
+			remaining = limit
+		}
+
+		w.Header().Set("X-RateLimit-Bucket", "abcd")
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit))
+		w.Header().Set("X-RateLimit-Reset-After", strconv.FormatFloat(reset.Sub(now).Seconds(), 'f', 3, 64))
+		if remaining == 0 {
+			s.limited.Inc()
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			writeRateLimited(w, "user", reset.Sub(now).Seconds())
+			return
+		}
+		remaining--
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	})
+	return s
+}
+
+// requestConcurrently exhausts the bucket and then sends n requests at once.
+func requestConcurrently(t *testing.T, srv *bucketServer, rl Ratelimiter, limit, n int) {
+	for i := 0; i < limit; i++ {
+		req, client := srv.request("/api/v10/channels/686053040863969305/messages")
+		_, err := rl.Request(client, req)
+		require.NoError(t, err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, client := srv.request("/api/v10/channels/686053040863969305/messages")
+			resp, err := rl.Request(client, req)
+			if assert.NoError(t, err) {
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestLeakyBucketRatelimiter_Bucket(t *testing.T) {
+	t.Parallel()
+
+	srv := newRateLimitServer(t, func(w http.ResponseWriter, hit int64) {
+		w.Header().Set("X-RateLimit-Bucket", "abcd")
+		w.Header().Set("X-RateLimit-Limit", "2")
+		w.Header().Set("X-RateLimit-Remaining", strconv.FormatInt(hit%2, 10))
+		w.Header().Set("X-RateLimit-Reset-After", "0.3")
+	})
+	rl := NewLeakyBucketRatelimiter()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		req, client := srv.request("/api/v10/channels/686053040863969305/messages")
+		resp, err := rl.Request(client, req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	require.GreaterOrEqual(t, time.Since(start), 250*time.Millisecond)
+
+	// Other channels have their own bucket.
+	start = time.Now()
+	req, client := srv.request("/api/v10/channels/872142459847733259/messages")
+	_, err := rl.Request(client, req)
+	require.NoError(t, err)
+	require.Less(t, time.Since(start), 250*time.Millisecond)
+}
+
+func TestLeakyBucketRatelimiter_ExhaustedConcurrent(t *testing.T) {
+	t.Parallel()
+
+	srv := newBucketServer(t, 2, 200*time.Millisecond)
+	rl := NewLeakyBucketRatelimiter()
+
+	// Every waiter needs its own slot, 6 requests take three more windows.
+	start := time.Now()
+	requestConcurrently(t, srv, rl, 2, 6)
+	require.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+	require.Zero(t, srv.limited.Load(), "requests were sent into an exhausted bucket")
+}
+
+func TestLeakyBucketRatelimiter_TooManyRequests(t *testing.T) {
+	t.Parallel()
+
+	srv := newRateLimitServer(t, func(w http.ResponseWriter, hit int64) {
+		w.Header().Set("X-RateLimit-Bucket", "abcd")
+		w.Header().Set("X-RateLimit-Limit", "5")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset-After", "0.2")
+		if hit == 1 {
+			writeRateLimited(w, "user", 0.2)
+		}
+	})
+	rl := NewLeakyBucketRatelimiter()
+
+	start := time.Now()
+	req, client := srv.request("/api/v10/guilds/686053040863969305/roles/872142459847733259")
+	resp, err := rl.Request(client, req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.EqualValues(t, 2, srv.hits.Load())
+	require.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+}
+
+func TestLeakyBucketRatelimiter_Global(t *testing.T) {
+	t.Parallel()
+
+	srv := newRateLimitServer(t, func(w http.ResponseWriter, hit int64) {
+		if hit == 1 {
+			writeRateLimited(w, "global", 0.3)
+		}
+	})
+	rl := NewLeakyBucketRatelimiter()
+
+	start := time.Now()
+	req, client := srv.request("/api/v10/users/@me")
+	_, err := rl.Request(client, req)
+	require.NoError(t, err)
+
+	// The global limit applies to every route.
+	req, client = srv.request("/api/v10/channels/686053040863969305")
+	_, err = rl.Request(client, req)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(start), 250*time.Millisecond)
+	require.EqualValues(t, 3, srv.hits.Load())
+}
+
+func TestLeakyBucketRatelimiter_GlobalLimit(t *testing.T) {
+	t.Parallel()
+
+	srv := newRateLimitServer(t, func(w http.ResponseWriter, hit int64) {})
+	rl := NewMemoryRatelimiter(&MemoryConf{GlobalLimit: 10})
+
+	start := time.Now()
+	for i := 0; i < 15; i++ {
+		req, client := srv.request("/api/v10/gateway/bot")
+		_, err := rl.Request(client, req)
+		require.NoError(t, err)
+	}
+	require.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+}
+
+func TestLeakyBucketRatelimiter_MaxRetries(t *testing.T) {
+	t.Parallel()
+
+	srv := newRateLimitServer(t, func(w http.ResponseWriter, hit int64) {
+		writeRateLimited(w, "shared", 0.01)
+	})
+	rl := NewMemoryRatelimiter(&MemoryConf{MaxRetries: 2})
+
+	req, client := srv.request("/api/v10/channels/686053040863969305/messages/872142459847733259/reactions/%F0%9F%91%8D/@me")
+	_, err := rl.Request(client, req)
+	require.ErrorIs(t, err, ErrMaxRetriesExceeded)
+	require.EqualValues(t, 3, srv.hits.Load())
+}
+
+func TestLeakyBucketRatelimiter_Context(t *testing.T) {
+	t.Parallel()
+
+	srv := newRateLimitServer(t, func(w http.ResponseWriter, hit int64) {
+		w.Header().Set("X-RateLimit-Bucket", "abcd")
+		w.Header().Set("X-RateLimit-Limit", "1")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset-After", "5")
+	})
+	rl := NewLeakyBucketRatelimiter()
+
+	req, client := srv.request("/api/v10/channels/686053040863969305/messages")
+	_, err := rl.Request(client, req)
+	require.NoError(t, err)
+
+	req, client = srv.request("/api/v10/channels/686053040863969305/messages")
+	req.ctx = FailFast(context.Background())
+	_, err = rl.Request(client, req)
+	var rlErr *ErrorRateLimited
+	require.ErrorAs(t, err, &rlErr)
+	require.False(t, rlErr.Global)
+	require.InDelta(t, 5*time.Second, rlErr.RetryAfter, float64(time.Second))
+
+	// A deadline shorter than the wait fails fast as well.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	req, client = srv.request("/api/v10/channels/686053040863969305/messages")
+	req.ctx = ctx
+	start := time.Now()
+	_, err = rl.Request(client, req)
+	require.ErrorAs(t, err, &rlErr)
+	require.Less(t, time.Since(start), 100*time.Millisecond)
+
+	// Cancelling the context ends the wait.
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	req, client = srv.request("/api/v10/channels/686053040863969305/messages")
+	req.ctx = ctx
+	start = time.Now()
+	_, err = rl.Request(client, req)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Less(t, time.Since(start), time.Second)
+	require.EqualValues(t, 1, srv.hits.Load())
+}
@@ -5,13 +5,32 @@ import (
 )
 
 type MemoryConf struct {
+	// MaxRetries is the number of times a 429 response is retried,
+	// defaults to DefaultMaxRetries. A negative value disables retries.
 	MaxRetries int
+	// GlobalLimit is the number of requests per second allowed across all
+	// routes, defaults to DefaultGlobalLimit.
+	GlobalLimit int
 }
 
-// NewMemoryRatelimiter returns a *LeakyBucketRatelimiter, but is kept for backward compatibility
+// NewMemoryRatelimiter returns a *LeakyBucketRatelimiter configured by conf.
 func NewMemoryRatelimiter(conf *MemoryConf) *LeakyBucketRatelimiter {
+	maxRetries := conf.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = DefaultMaxRetries
+	} else if maxRetries < 0 {
+		maxRetries = 0
+	}
+
+	globalLimit := conf.GlobalLimit
+	if globalLimit <= 0 {
+		globalLimit = DefaultGlobalLimit
+	}
+
 	return &LeakyBucketRatelimiter{
-		buckets:  make(map[string]*rate.Limiter),
-		routeMap: make(map[string]string),
+		buckets:    make(map[string]*Bucket),
+		routeMap:   make(map[string]string),
+		global:     rate.NewLimiter(rate.Limit(globalLimit), globalLimit),
+		maxRetries: maxRetries,
 	}
 }
@@ -2,175 +2,449 @@ package rest
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
-	"github.com/go-redsync/redsync/v4"
-	"github.com/go-redsync/redsync/v4/redis/goredis/v9"
 	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
 )
 
 var _ Ratelimiter = (*RedisRatelimiter)(nil)
 
+const (
+	defaultRedisRatelimitPrefix = "{wumpgo:ratelimit}:"
+
+	// redisRouteTTL is how long the bucket of a route is remembered after it
+	// was last seen.
+	redisRouteTTL = 24 * time.Hour
+)
+
 type RedisConf struct {
-	Options    *redis.Options
+	// Client is used if set, otherwise a client is created from Options. It
+	// may be a cluster client.
+	Client  redis.UniversalClient
+	Options *redis.Options
+	// MaxRetries is the number of times a 429 response is retried,
+	// defaults to DefaultMaxRetries. A negative value disables retries.
 	MaxRetries int
+	// GlobalLimit is the number of requests per second allowed across all
+	// routes and processes, defaults to DefaultGlobalLimit.
+	GlobalLimit int
+	// Prefix is prepended to every key, defaults to "{wumpgo:ratelimit}:".
+	// Scripts use several keys at once, so with Redis Cluster the prefix
+	// must contain a hash tag to keep every key in the same slot.
+	Prefix string
 }
 
 func NewRedisRatelimiter(conf *RedisConf) (*RedisRatelimiter, error) {
-	// TODO: Allow this client to be injected.
-	r := redis.NewClient(conf.Options)
+	r := conf.Client
+	if r == nil {
+		r = redis.NewClient(conf.Options)
+	}
 	if _, err := r.Ping(context.Background()).Result(); err != nil {
 		return nil, err
 	}
+
+	maxRetries := conf.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = DefaultMaxRetries
+	} else if maxRetries < 0 {
+		maxRetries = 0
+	}
+
+	globalLimit := conf.GlobalLimit
+	if globalLimit <= 0 {
+		globalLimit = DefaultGlobalLimit
+	}
+
+	prefix := conf.Prefix
+	if prefix == "" {
+		prefix = defaultRedisRatelimitPrefix
+	}
+
 	return &RedisRatelimiter{
-		redis:      r,
-		redsync:    redsync.New(goredis.NewPool(r)),
-		MaxRetries: conf.MaxRetries,
+		redis:       r,
+		prefix:      prefix,
+		globalLimit: globalLimit,
+		MaxRetries:  maxRetries,
 	}, nil
 }
 
+// RedisRatelimiter shares rate limit state between processes through Redis.
+//
+// It follows the same rules as LeakyBucketRatelimiter, but every decision is
+// made by a Lua script so reserving a slot takes a single round trip and
+// needs no lock. Bucket hashes learned by one process are used by all of
+// them, and are forgotten a day after a route was last used.
+//
+// The scripts look up the bucket of a route themselves, so bucket keys
+// aren't passed in KEYS. They share the hash tag of the prefix with the
+// route key, which keeps them in the same slot with Redis Cluster.
 type RedisRatelimiter struct {
-	redis      *redis.Client
-	redsync    *redsync.Redsync
-	MaxRetries int
+	redis       redis.UniversalClient
+	prefix      string
+	globalLimit int
+	MaxRetries  int
+}
+
+// reserveScript claims a slot in the bucket of a route and in the global
+// limit, returning {status, wait in ms, global}. Status is 1 if a slot was
+// claimed, 2 if the bucket is exhausted and the caller has to try again
+// after waiting, and 0 if the wait would be longer than the max wait. The
+// global limit is counted per second in a hash, fields of past seconds are
+// removed.
+//
+// KEYS: route, global reset, global counts
+// ARGV: now in ms, max wait in ms, global limit, prefix, major parameter
+var reserveScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local max = tonumber(ARGV[2])
+local globalLimit = tonumber(ARGV[3])
+local wait = 0
+
+if globalLimit > 0 then
+	local reset = tonumber(redis.call('GET', KEYS[2]) or '0')
+	if reset > now then
+		wait = reset - now
+	end
+	if max >= 0 and wait > max then
+		return {0, wait, 1}
+	end
+end
+
+local bucket = nil
+local limit, remaining, reset, window
+local hash = redis.call('GET', KEYS[1])
+if hash then
+	bucket = ARGV[4] .. 'bucket:' .. hash
+	if ARGV[5] ~= '' then
+		bucket = bucket .. ':' .. ARGV[5]
+	end
+	local state = redis.call('HMGET', bucket, 'limit', 'remaining', 'reset', 'window')
+	limit = tonumber(state[1])
+	remaining = tonumber(state[2]) or 0
+	reset = tonumber(state[3]) or 0
+	window = tonumber(state[4]) or 0
+end
+
+if limit then
+	if now >= reset then
+		remaining = limit
+		reset = now + window
+	end
+	if remaining <= 0 then
+		-- Nothing is claimed from the next window, the caller competes for
+		-- it with everyone else once it starts.
+		local d = reset - now
+		if d > wait then
+			wait = d
+		end
+		if max >= 0 and wait > max then
+			return {0, wait, 0}
+		end
+		return {2, wait, 0}
+	end
+end
+
+if globalLimit > 0 then
+	local current = math.floor(now / 1000)
+	local counts = redis.call('HGETALL', KEYS[3])
+	for i = 1, #counts, 2 do
+		if tonumber(counts[i]) < current then
+			redis.call('HDEL', KEYS[3], counts[i])
+		end
+	end
+
+	local start = math.floor((now + wait) / 1000)
+	local second = nil
+	for i = 0, 59 do
+		if tonumber(redis.call('HGET', KEYS[3], start + i) or '0') < globalLimit then
+			second = start + i
+			break
+		end
+	end
+	if not second then
+		return {0, 60000, 1}
+	end
+	local d = second * 1000 - now
+	if d > wait then
+		if max >= 0 and d > max then
+			return {0, d, 1}
+		end
+		wait = d
+	end
+	redis.call('HINCRBY', KEYS[3], second, 1)
+	redis.call('PEXPIRE', KEYS[3], (second + 2) * 1000 - now)
+end
+
+if limit then
+	redis.call('HSET', bucket, 'remaining', remaining - 1, 'reset', reset)
+end
+
+return {1, wait, 0}
+`)
+
+// updateScript records the rate limit headers of a response.
+//
+// KEYS: route, bucket
+// ARGV: bucket hash, limit, remaining, reset after in ms, now in ms,
+// route TTL in ms
+var updateScript = redis.NewScript(`
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[6])
+
+local resetAfter = tonumber(ARGV[4])
+local remaining = tonumber(ARGV[3])
+local reset = tonumber(ARGV[5]) + resetAfter
+local state = redis.call('HMGET', KEYS[2], 'remaining', 'reset', 'window')
+local window = tonumber(state[3]) or 0
+
+-- Responses to requests sent earlier in the same window don't count the
+-- slots claimed since.
+local current = tonumber(state[1])
+if current and remaining > current and reset < tonumber(state[2]) + window / 2 then
+	remaining = current
+end
+
+if resetAfter > window then
+	window = resetAfter
+end
+
+redis.call('HSET', KEYS[2], 'limit', ARGV[2], 'remaining', remaining, 'reset', reset, 'window', window)
+redis.call('PEXPIRE', KEYS[2], resetAfter + 60000)
+return 1
+`)
+
+// exhaustGlobalScript records a global 429.
+//
+// KEYS: global reset
+// ARGV: retry after in ms, now in ms
+var exhaustGlobalScript = redis.NewScript(`
+local retryAfter = tonumber(ARGV[1])
+local reset = tonumber(ARGV[2]) + retryAfter
+
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+if reset > current then
+	redis.call('SET', KEYS[1], reset, 'PX', retryAfter + 1)
+end
+return 1
+`)
+
+// exhaustScript records a 429 of the bucket of a route, returning 0 if the
+// bucket isn't known and the caller has to wait itself.
+//
+// KEYS: route
+// ARGV: retry after in ms, now in ms, prefix, major parameter
+var exhaustScript = redis.NewScript(`
+local retryAfter = tonumber(ARGV[1])
+local reset = tonumber(ARGV[2]) + retryAfter
+
+local hash = redis.call('GET', KEYS[1])
+if not hash then
+	return 0
+end
+
+local bucket = ARGV[3] .. 'bucket:' .. hash
+if ARGV[4] ~= '' then
+	bucket = bucket .. ':' .. ARGV[4]
+end
+
+if not redis.call('HGET', bucket, 'limit') then
+	return 0
+end
+
+local current = tonumber(redis.call('HGET', bucket, 'reset') or '0')
+if reset > current then
+	current = reset
+end
+redis.call('HSET', bucket, 'remaining', 0, 'reset', current)
+redis.call('PEXPIRE', bucket, retryAfter + 60000)
+return 1
+`)
+
+func (r *RedisRatelimiter) routeKey(route string) string {
+	return r.prefix + "route:" + route
 }
 
-func (r *RedisRatelimiter) getSleepTime(bucketID string) (time.Duration, error) {
-	remainingRequests, err := r.redis.Get(context.Background(), bucketID+"remaining").Int()
-	if err != nil && err != redis.Nil {
-		return time.Duration(0), err
+// bucketKey returns the key of a bucket, buckets are shared by routes with
+// the same hash and major parameter.
+func (r *RedisRatelimiter) bucketKey(hash, major string) string {
+	key := r.prefix + "bucket:" + hash
+	if major != "" {
+		key += ":" + major
 	}
-	if remainingRequests < 1 && err != redis.Nil {
-		remainingTime, err := r.redis.Get(context.Background(), bucketID+"time").Int64()
+	return key
+}
+
+// wait waits until a request on route can be sent, claiming a slot in its
+// bucket and the global limit. Waits for an exhausted bucket are retried
+// once the bucket resets, as other requests may claim the new window first.
+func (r *RedisRatelimiter) wait(ctx context.Context, route, major string, global bool, path string) error {
+	max := maxWait(ctx)
+	for {
+		delay, claimed, err := r.reserve(ctx, route, major, global, max)
 		if err != nil {
-			return time.Duration(0), err
+			return err
 		}
-		now := time.Now()
-		reset := time.Unix(0, remainingTime)
-		if now.Before(reset) {
-			return reset.Sub(now), nil
+		if delay > 0 {
+			zerolog.Ctx(ctx).Debug().
+				Dur("delay", delay).
+				Str("path", path).
+				Msg("Ratelimited request")
+			if err := sleep(ctx, delay); err != nil {
+				return err
+			}
+			if max >= 0 {
+				max -= delay
+				if max < 0 {
+					max = 0
+				}
+			}
+		}
+		if claimed {
+			return nil
 		}
 	}
-	global, err := r.redis.Get(context.Background(), "global").Int64()
-	if err != nil && err != redis.Nil {
-		return time.Duration(0), err
+}
+
+// reserve claims a slot for route and returns how long to wait before using
+// it. If claimed is false the bucket is exhausted and reserve has to be
+// called again after the wait.
+func (r *RedisRatelimiter) reserve(ctx context.Context, route, major string, global bool, maxWait time.Duration) (delay time.Duration, claimed bool, err error) {
+	globalLimit := 0
+	if global {
+		globalLimit = r.globalLimit
 	}
-	now := time.Now()
-	globalExpiry := time.Unix(0, global)
-	if globalExpiry.After(now) {
-		return globalExpiry.Sub(now), nil
+
+	max := int64(-1)
+	if maxWait >= 0 {
+		max = maxWait.Milliseconds()
 	}
-	return time.Duration(0), nil
-}
 
-func (r *RedisRatelimiter) acquireLock(bucketID string, opts ...redsync.Option) (*redsync.Mutex, error) {
-	mutex := r.redsync.NewMutex(bucketID, opts...)
-	if err := mutex.Lock(); err != nil {
-		return nil, err
+	res, err := reserveScript.Run(ctx, r.redis,
+		[]string{r.routeKey(route), r.prefix + "global", r.prefix + "global:counts"},
+		time.Now().UnixMilli(), max, globalLimit, r.prefix, major,
+	).Int64Slice()
+	if err != nil {
+		return 0, false, err
 	}
-	if waitTime, err := r.getSleepTime(bucketID); err != nil {
-		return nil, err
-	} else if waitTime > time.Duration(0) {
-		time.Sleep(waitTime)
+
+	delay = time.Duration(res[1]) * time.Millisecond
+	if res[0] == 0 {
+		return 0, false, &ErrorRateLimited{RetryAfter: delay, Global: res[2] == 1}
 	}
-	return mutex, nil
+	return delay, res[0] == 1, nil
 }
 
-func (r *RedisRatelimiter) updateBucket(key string, resp *DiscordResponse) error {
-	if resp == nil {
+// update records the rate limit headers of a response.
+func (r *RedisRatelimiter) update(ctx context.Context, route, major string, h http.Header) error {
+	hash := h.Get("X-RateLimit-Bucket")
+	if hash == "" {
 		return nil
 	}
 
-	headers := resp.Header
+	limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return nil
+	}
 
-	remaining := headers.Get("X-RateLimit-Remaining")
-	reset := headers.Get("X-RateLimit-Reset")
-	global := headers.Get("X-RateLimit-Global")
-	resetAfter := headers.Get("X-RateLimit-Reset-After")
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return nil
+	}
 
-	if resetAfter != "" {
-		parsedAfter, err := strconv.ParseFloat(resetAfter, 64)
-		if err != nil {
-			return err
-		}
+	resetAfter, err := strconv.ParseFloat(h.Get("X-RateLimit-Reset-After"), 64)
+	if err != nil {
+		return nil
+	}
 
-		resetAt := time.Now().Add(time.Duration(parsedAfter) * time.Millisecond)
+	return updateScript.Run(ctx, r.redis, []string{r.routeKey(route), r.bucketKey(hash, major)},
+		hash, limit, remaining, int64(resetAfter*1000), time.Now().UnixMilli(), redisRouteTTL.Milliseconds(),
+	).Err()
+}
 
-		if global != "" {
-			if err = r.redis.Set(context.Background(), "global", resetAt.UnixNano(), time.Duration(0)).Err(); err != nil {
-				return err
-			}
-		} else {
-			if err = r.redis.Set(context.Background(), key+"time", resetAt.UnixNano(), time.Duration(0)).Err(); err != nil {
-				return err
-			}
-		}
-	} else if reset != "" {
-		unix, err := strconv.ParseInt(reset, 10, 64)
-		if err != nil {
-			return err
-		}
+// exhaust records a 429 and returns how long Discord asked us to wait.
+// tracked is false if the caller has to wait itself.
+func (r *RedisRatelimiter) exhaust(ctx context.Context, route, major string, resp *DiscordResponse) (retryAfter time.Duration, tracked bool, err error) {
+	var body rateLimitResponse
+	_ = json.Unmarshal(resp.Body, &body)
 
-		resetTime := time.Unix(unix, 0).Add(time.Millisecond * 250)
-		if err = r.redis.Set(context.Background(), key+"time", resetTime.UnixNano(), time.Duration(0)).Err(); err != nil {
-			return err
+	retryAfter = time.Duration(body.RetryAfter * float64(time.Second))
+	if retryAfter <= 0 {
+		if secs, err := strconv.ParseFloat(resp.Header.Get("Retry-After"), 64); err == nil {
+			retryAfter = time.Duration(secs * float64(time.Second))
 		}
 	}
 
-	if remaining != "" {
-		parsedRemaining, err := strconv.Atoi(remaining)
-		if err != nil {
-			return err
-		}
-		if err = r.redis.Set(context.Background(), key+"remaining", parsedRemaining, time.Duration(0)).Err(); err != nil {
-			return err
-		}
+	now := time.Now().UnixMilli()
+
+	var res int
+	if body.Global || resp.Header.Get("X-RateLimit-Global") == "true" || resp.Header.Get("X-RateLimit-Scope") == "global" {
+		res, err = exhaustGlobalScript.Run(ctx, r.redis, []string{r.prefix + "global"}, retryAfter.Milliseconds(), now).Int()
+	} else {
+		res, err = exhaustScript.Run(ctx, r.redis, []string{r.routeKey(route)}, retryAfter.Milliseconds(), now, r.prefix, major).Int()
+	}
+	if err != nil {
+		return retryAfter, false, err
 	}
-	return nil
+
+	return retryAfter, res == 1, nil
 }
 
-func (r *RedisRatelimiter) requestLocked(httpClient HTTPClient, req *request, bucketID string, retries int) (*DiscordResponse, error) {
-	if r.MaxRetries > 0 && r.MaxRetries < retries {
-		return nil, ErrMaxRetriesExceeded
+func (r *RedisRatelimiter) Request(httpClient HTTPClient, req *request) (*DiscordResponse, error) {
+	ctx := req.ctx
+	if ctx == nil {
+		ctx = context.Background()
 	}
 
-	resp, err := httpClient.Request(req)
+	u, err := url.Parse(req.path)
 	if err != nil {
-		_ = r.updateBucket(bucketID, resp)
 		return nil, err
 	}
+	route := requestRoute(req.method, u.Path)
+	major := majorParameter(u.Path)
 
-	if err = r.updateBucket(bucketID, resp); err != nil {
-		return nil, err
-	}
+	for retries := 0; ; retries++ {
+		if err := r.wait(ctx, route, major, !req.omitAuth, u.Path); err != nil {
+			return nil, err
+		}
 
-	switch resp.StatusCode {
-	case http.StatusTooManyRequests:
-		delay, err := r.getSleepTime(bucketID)
+		resp, err := httpClient.Request(req)
 		if err != nil {
 			return nil, err
 		}
-		if delay > time.Duration(0) {
-			time.Sleep(delay)
+
+		if err := r.update(ctx, route, major, resp.Header); err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Msg("Failed to update rate limit bucket")
 		}
-		return r.requestLocked(httpClient, req, bucketID, retries+1)
-	case http.StatusBadGateway:
-		return r.requestLocked(httpClient, req, bucketID, retries+1)
-	}
 
-	return resp, nil
-}
+		if resp.StatusCode != http.StatusTooManyRequests {
+			return resp, nil
+		}
 
-func (r *RedisRatelimiter) Request(httpClient HTTPClient, req *request) (*DiscordResponse, error) {
-	bucketID := getBucketID(req.path)
-	mutex, err := r.acquireLock(bucketID)
-	if err != nil {
-		return nil, err
+		if retries >= r.MaxRetries {
+			return resp, ErrMaxRetriesExceeded
+		}
+
+		retryAfter, tracked, err := r.exhaust(ctx, route, major, resp)
+		if err != nil {
+			return nil, err
+		}
+		zerolog.Ctx(ctx).Debug().
+			Dur("retry_after", retryAfter).
+			Str("scope", resp.Header.Get("X-RateLimit-Scope")).
+			Str("path", u.Path).
+			Int("retry", retries+1).
+			Msg("Received 429, retrying")
+		if !tracked {
+			if max := maxWait(ctx); max >= 0 && retryAfter > max {
+				return nil, &ErrorRateLimited{RetryAfter: retryAfter}
+			}
+			if err := sleep(ctx, retryAfter); err != nil {
+				return nil, err
+			}
+		}
 	}
-	defer func() {
-		_, _ = mutex.Unlock()
-	}()
-	return r.requestLocked(httpClient, req, bucketID, 0)
 }
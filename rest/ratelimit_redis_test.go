@@ -0,0 +1,177 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRedisRatelimiters(t *testing.T, n int) []*RedisRatelimiter {
+	mr := miniredis.RunT(t)
+	rls := make([]*RedisRatelimiter, n)
+	for i := range rls {
+		rl, err := NewRedisRatelimiter(&RedisConf{
+			Client: redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+		})
+		require.NoError(t, err)
+		rls[i] = rl
+	}
+	return rls
+}
+
+func TestRedisRatelimiter_SharedBucket(t *testing.T) {
+	t.Parallel()
+
+	srv := newRateLimitServer(t, func(w http.ResponseWriter, hit int64) {
+		w.Header().Set("X-RateLimit-Bucket", "abcd")
+		w.Header().Set("X-RateLimit-Limit", "2")
+		w.Header().Set("X-RateLimit-Remaining", strconv.FormatInt(hit%2, 10))
+		w.Header().Set("X-RateLimit-Reset-After", "0.3")
+	})
+	rls := newTestRedisRatelimiters(t, 2)
+
+	// The bucket learned by the first process limits the second one.
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		req, client := srv.request("/api/v10/channels/686053040863969305/messages")
+		resp, err := rls[i%2].Request(client, req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	require.GreaterOrEqual(t, time.Since(start), 250*time.Millisecond)
+
+	start = time.Now()
+	req, client := srv.request("/api/v10/channels/872142459847733259/messages")
+	_, err := rls[1].Request(client, req)
+	require.NoError(t, err)
+	require.Less(t, time.Since(start), 250*time.Millisecond)
+
+	// Waits can fail fast instead.
+	for i := 0; i < 2; i++ {
+		req, client = srv.request("/api/v10/channels/872142459847733259/messages")
+		req.ctx = FailFast(context.Background())
+		_, err = rls[0].Request(client, req)
+	}
+	var rlErr *ErrorRateLimited
+	require.ErrorAs(t, err, &rlErr)
+	require.False(t, rlErr.Global)
+}
+
+func TestRedisRatelimiter_ExhaustedConcurrent(t *testing.T) {
+	t.Parallel()
+
+	srv := newBucketServer(t, 2, 200*time.Millisecond)
+	rls := newTestRedisRatelimiters(t, 1)
+
+	// Every waiter needs its own slot, 6 requests take three more windows.
+	start := time.Now()
+	requestConcurrently(t, srv, rls[0], 2, 6)
+	require.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+	require.Zero(t, srv.limited.Load(), "requests were sent into an exhausted bucket")
+}
+
+func TestRedisRatelimiter_TooManyRequests(t *testing.T) {
+	t.Parallel()
+
+	srv := newRateLimitServer(t, func(w http.ResponseWriter, hit int64) {
+		switch hit {
+		case 1:
+			w.Header().Set("X-RateLimit-Bucket", "abcd")
+			w.Header().Set("X-RateLimit-Limit", "5")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset-After", "0.2")
+			writeRateLimited(w, "user", 0.2)
+		case 2:
+			writeRateLimited(w, "global", 0.3)
+		}
+	})
+	rls := newTestRedisRatelimiters(t, 2)
+
+	start := time.Now()
+	req, client := srv.request("/api/v10/guilds/686053040863969305/roles/872142459847733259")
+	resp, err := rls[0].Request(client, req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.EqualValues(t, 3, srv.hits.Load())
+	require.GreaterOrEqual(t, time.Since(start), 450*time.Millisecond)
+
+	// The global limit is shared as well.
+	srv.hits.Store(1)
+	req, client = srv.request("/api/v10/users/@me")
+	req.ctx = FailFast(context.Background())
+	_, err = rls[0].Request(client, req)
+	var rlErr *ErrorRateLimited
+	require.ErrorAs(t, err, &rlErr)
+	require.True(t, rlErr.Global)
+
+	req, client = srv.request("/api/v10/gateway/bot")
+	req.ctx = FailFast(context.Background())
+	_, err = rls[1].Request(client, req)
+	require.ErrorAs(t, err, &rlErr)
+	require.True(t, rlErr.Global)
+	require.EqualValues(t, 2, srv.hits.Load())
+}
+
+func TestRedisRatelimiter_GlobalLimit(t *testing.T) {
+	t.Parallel()
+
+	srv := newRateLimitServer(t, func(w http.ResponseWriter, hit int64) {})
+	mr := miniredis.RunT(t)
+	rl, err := NewRedisRatelimiter(&RedisConf{
+		Client:      redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+		GlobalLimit: 5,
+	})
+	require.NoError(t, err)
+
+	start := time.Now()
+	for i := 0; i < 11; i++ {
+		req, client := srv.request("/api/v10/gateway/bot")
+		_, err := rl.Request(client, req)
+		require.NoError(t, err)
+	}
+	require.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestRedisRatelimiter_Keys(t *testing.T) {
+	t.Parallel()
+
+	srv := newRateLimitServer(t, func(w http.ResponseWriter, hit int64) {
+		w.Header().Set("X-RateLimit-Bucket", "abcd")
+		w.Header().Set("X-RateLimit-Limit", "5")
+		w.Header().Set("X-RateLimit-Remaining", "4")
+		w.Header().Set("X-RateLimit-Reset-After", "1")
+	})
+	mr := miniredis.RunT(t)
+	rl, err := NewRedisRatelimiter(&RedisConf{
+		Client: redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+	})
+	require.NoError(t, err)
+
+	for _, id := range []string{"686053040863969305", "872142459847733259"} {
+		req, client := srv.request("/api/v10/channels/" + id + "/messages")
+		_, err := rl.Request(client, req)
+		require.NoError(t, err)
+	}
+
+	// Every key shares the hash tag of the prefix and expires.
+	keys := mr.Keys()
+	require.ElementsMatch(t, []string{
+		"{wumpgo:ratelimit}:global:counts",
+		"{wumpgo:ratelimit}:route:GET:channels:686053040863969305:messages",
+		"{wumpgo:ratelimit}:route:GET:channels:872142459847733259:messages",
+		"{wumpgo:ratelimit}:bucket:abcd:686053040863969305",
+		"{wumpgo:ratelimit}:bucket:abcd:872142459847733259",
+	}, keys)
+	for _, key := range keys {
+		require.Positive(t, mr.TTL(key), key)
+	}
+
+	mr.FastForward(redisRouteTTL)
+	require.Empty(t, mr.Keys())
+}
@@ -5,16 +5,6 @@ import (
 	"net/http"
 )
 
-type ErrorREST struct {
-	Message string
-	Status  int
-	Body    json.RawMessage
-}
-
-func (r ErrorREST) Error() string {
-	return r.Message
-}
-
 type DiscordResponse struct {
 	Body       []byte
 	StatusCode int
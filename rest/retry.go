@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/rs/zerolog"
+)
+
+// RetryPolicy configures how requests failing with a network error or a
+// transient 5xx status are retried. Rate limits are handled by the
+// Ratelimiter, and every retry goes through it again.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// InitialInterval is the wait before the first retry. Each following
+	// wait is Multiplier times longer, up to MaxInterval.
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+	// Jitter randomizes each wait by up to this fraction of it.
+	Jitter float64
+	// Statuses are the response status codes that are retried.
+	Statuses []int
+	// Methods are the HTTP methods that are retried. Requests with other
+	// methods are only retried if their context was passed to WithRetries.
+	Methods []string
+}
+
+// DefaultRetryPolicy retries idempotent requests up to 3 times in total.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:     3,
+	InitialInterval: 100 * time.Millisecond,
+	MaxInterval:     2 * time.Second,
+	Multiplier:      2,
+	Jitter:          0.5,
+	Statuses: []int{
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	},
+	Methods: []string{
+		http.MethodGet,
+		http.MethodHead,
+		http.MethodOptions,
+		http.MethodPut,
+		http.MethodDelete,
+	},
+}
+
+type retryKey struct{}
+
+// WithRetries returns a context allowing requests made with it to be
+// retried even if their method isn't idempotent, such as a POST that is
+// safe to send twice.
+func WithRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryKey{}, true)
+}
+
+func (p *RetryPolicy) backoff() backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = p.InitialInterval
+	b.MaxInterval = p.MaxInterval
+	b.Multiplier = p.Multiplier
+	b.RandomizationFactor = p.Jitter
+	b.MaxElapsedTime = 0
+	b.Reset()
+	return b
+}
+
+func (p *RetryPolicy) retryable(req *request) bool {
+	if req.ctx != nil {
+		if ok, _ := req.ctx.Value(retryKey{}).(bool); ok {
+			return true
+		}
+	}
+	for _, m := range p.Methods {
+		if m == req.method {
+			return true
+		}
+	}
+	return false
+}
+
+// transient reports whether a request that ended with resp and err may
+// succeed if sent again.
+func (p *RetryPolicy) transient(req *request, resp *DiscordResponse, err error) bool {
+	if err != nil {
+		if req.ctx != nil && req.ctx.Err() != nil {
+			return false
+		}
+		var netErr net.Error
+		return errors.As(err, &netErr)
+	}
+	for _, s := range p.Statuses {
+		if s == resp.StatusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// do calls send until it succeeds, fails permanently or the policy gives up.
+func (p *RetryPolicy) do(req *request, send func() (*DiscordResponse, error)) (*DiscordResponse, error) {
+	if p == nil || p.MaxAttempts <= 1 || !p.retryable(req) {
+		return send()
+	}
+
+	b := p.backoff()
+	for attempt := 1; ; attempt++ {
+		resp, err := send()
+		if attempt >= p.MaxAttempts || !p.transient(req, resp, err) {
+			return resp, err
+		}
+
+		wait := b.NextBackOff()
+		if max := maxWait(req.ctx); max >= 0 && wait > max {
+			return resp, err
+		}
+
+		l := zerolog.Ctx(req.ctx).Debug().Dur("wait", wait).Str("path", req.path).Int("attempt", attempt)
+		if err != nil {
+			l = l.Err(err)
+		} else {
+			l = l.Int("status", resp.StatusCode)
+		}
+		l.Msg("Retrying request")
+
+		if err := sleep(req.ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := DefaultRetryPolicy
+	policy.InitialInterval = time.Millisecond
+	policy.MaxInterval = 5 * time.Millisecond
+
+	tt := []struct {
+		name     string
+		method   string
+		ctx      context.Context
+		failures int64
+		status   int
+
+		wantStatus int
+		wantHits   int64
+	}{
+		{
+			name:       "get recovers",
+			method:     http.MethodGet,
+			ctx:        context.Background(),
+			failures:   2,
+			status:     http.StatusBadGateway,
+			wantStatus: http.StatusOK,
+			wantHits:   3,
+		},
+		{
+			name:       "get gives up",
+			method:     http.MethodGet,
+			ctx:        context.Background(),
+			failures:   5,
+			status:     http.StatusServiceUnavailable,
+			wantStatus: http.StatusServiceUnavailable,
+			wantHits:   3,
+		},
+		{
+			name:       "post not retried",
+			method:     http.MethodPost,
+			ctx:        context.Background(),
+			failures:   1,
+			status:     http.StatusBadGateway,
+			wantStatus: http.StatusBadGateway,
+			wantHits:   1,
+		},
+		{
+			name:       "post opted in",
+			method:     http.MethodPost,
+			ctx:        WithRetries(context.Background()),
+			failures:   1,
+			status:     http.StatusBadGateway,
+			wantStatus: http.StatusOK,
+			wantHits:   2,
+		},
+		{
+			name:       "client errors not retried",
+			method:     http.MethodGet,
+			ctx:        context.Background(),
+			failures:   1,
+			status:     http.StatusNotFound,
+			wantStatus: http.StatusNotFound,
+			wantHits:   1,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := newRateLimitServer(t, func(w http.ResponseWriter, hit int64) {
+				if hit <= tc.failures {
+					w.WriteHeader(tc.status)
+				}
+			})
+			c := New(WithRetryPolicy(policy)).(*Client)
+
+			resp, err := NewRequest().WithContext(tc.ctx).Method(tc.method).Path(srv.URL + "/api/v10/gateway/bot").SendRaw(c)
+			if tc.wantStatus == http.StatusOK {
+				require.NoError(t, err)
+			} else {
+				e, ok := AsErrorREST(err)
+				require.True(t, ok)
+				require.Equal(t, tc.wantStatus, e.Status)
+			}
+			require.Equal(t, tc.wantStatus, resp.StatusCode)
+			require.Equal(t, tc.wantHits, srv.hits.Load())
+		})
+	}
+}
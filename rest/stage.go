@@ -0,0 +1,95 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"wumpgo.dev/wumpgo/objects"
+)
+
+type CreateStageInstanceParams struct {
+	ChannelID objects.Snowflake `json:"channel_id"`
+	Topic     string            `json:"topic"`
+	// PrivacyLevel defaults to objects.PrivacyLevelGuildOnly.
+	PrivacyLevel objects.PrivacyLevel `json:"privacy_level,omitempty"`
+	// SendStartNotification notifies @everyone that the stage started,
+	// requires the MentionEveryone permission.
+	SendStartNotification bool               `json:"send_start_notification,omitempty"`
+	GuildScheduledEventID *objects.Snowflake `json:"guild_scheduled_event_id,omitempty"`
+	Reason                string             `json:"-"`
+}
+
+type ModifyStageInstanceParams struct {
+	Topic        string               `json:"topic,omitempty"`
+	PrivacyLevel objects.PrivacyLevel `json:"privacy_level,omitempty"`
+	Reason       string               `json:"-"`
+}
+
+func (c *Client) CreateStageInstance(ctx context.Context, params *CreateStageInstanceParams) (*objects.StageInstance, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	stage := &objects.StageInstance{}
+	err = NewRequest().
+		Method(http.MethodPost).
+		WithContext(ctx).
+		Path(StageInstancesFmt).
+		ContentType(JsonContentType).
+		Body(data).
+		Bind(stage).
+		Reason(params.Reason).
+		Send(c)
+
+	return stage, err
+}
+
+func (c *Client) GetStageInstance(ctx context.Context, channel objects.Snowflake) (*objects.StageInstance, error) {
+	stage := &objects.StageInstance{}
+	err := NewRequest().
+		Method(http.MethodGet).
+		WithContext(ctx).
+		Path(fmt.Sprintf(StageInstanceFmt, channel)).
+		ContentType(JsonContentType).
+		Bind(stage).
+		Send(c)
+
+	return stage, err
+}
+
+func (c *Client) ModifyStageInstance(ctx context.Context, channel objects.Snowflake, params *ModifyStageInstanceParams) (*objects.StageInstance, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	stage := &objects.StageInstance{}
+	err = NewRequest().
+		Method(http.MethodPatch).
+		WithContext(ctx).
+		Path(fmt.Sprintf(StageInstanceFmt, channel)).
+		ContentType(JsonContentType).
+		Body(data).
+		Bind(stage).
+		Reason(params.Reason).
+		Send(c)
+
+	return stage, err
+}
+
+func (c *Client) DeleteStageInstance(ctx context.Context, channel objects.Snowflake, reason ...string) error {
+	req := NewRequest().
+		Method(http.MethodDelete).
+		WithContext(ctx).
+		Path(fmt.Sprintf(StageInstanceFmt, channel)).
+		ContentType(JsonContentType)
+
+	if len(reason) > 0 {
+		req.Reason(reason[0])
+	}
+
+	return req.Send(c)
+}
@@ -0,0 +1,94 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wumpgo.dev/wumpgo/objects"
+)
+
+func TestCreateStageInstance(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v10/stage-instances", r.URL.Path)
+		assert.Equal(t, "town hall", r.Header.Get(XAuditLogReasonHeader))
+
+		body := decodeBody(t, r)
+		assert.Equal(t, "872142459847733259", body["channel_id"])
+		assert.Equal(t, "Q&A", body["topic"])
+		assert.EqualValues(t, objects.PrivacyLevelGuildOnly, body["privacy_level"])
+		assert.NotContains(t, body, "guild_scheduled_event_id")
+
+		_, _ = w.Write([]byte(`{"id": "1", "guild_id": "686053040863969305", "channel_id": "872142459847733259", "topic": "Q&A", "privacy_level": 2}`))
+	})
+
+	stage, err := c.CreateStageInstance(context.Background(), &CreateStageInstanceParams{
+		ChannelID:    872142459847733259,
+		Topic:        "Q&A",
+		PrivacyLevel: objects.PrivacyLevelGuildOnly,
+		Reason:       "town hall",
+	})
+	require.NoError(t, err)
+	require.Equal(t, objects.PrivacyLevelGuildOnly, stage.PrivacyLevel)
+}
+
+func TestModifyUserVoiceState(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/api/v10/guilds/686053040863969305/voice-states/123456789", r.URL.Path)
+
+		body := decodeBody(t, r)
+		assert.Equal(t, "872142459847733259", body["channel_id"])
+		assert.Equal(t, false, body["suppress"])
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	suppress := false
+	err := c.ModifyUserVoiceState(context.Background(), 686053040863969305, 123456789, &ModifyUserVoiceStateParams{
+		ChannelID: 872142459847733259,
+		Suppress:  &suppress,
+	})
+	require.NoError(t, err)
+}
+
+func TestModifyCurrentUserVoiceState_RequestToSpeak(t *testing.T) {
+	raised := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name    string
+		request *Nullable[objects.Time]
+		want    interface{}
+		present bool
+	}{
+		{name: "unchanged"},
+		{name: "raised", request: NewNullable(objects.Time{Time: raised}), want: "2023-01-01T12:00:00Z", present: true},
+		{name: "lowered", request: Null[objects.Time](), want: nil, present: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPatch, r.Method)
+				assert.Equal(t, "/api/v10/guilds/686053040863969305/voice-states/@me", r.URL.Path)
+
+				body := decodeBody(t, r)
+				got, ok := body["request_to_speak_timestamp"]
+				assert.Equal(t, tc.present, ok)
+				assert.Equal(t, tc.want, got)
+
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			err := c.ModifyCurrentUserVoiceState(context.Background(), 686053040863969305, &ModifyCurrentUserVoiceStateParams{
+				ChannelID:               872142459847733259,
+				RequestToSpeakTimestamp: tc.request,
+			})
+			require.NoError(t, err)
+		})
+	}
+}
@@ -2,6 +2,8 @@ package rest
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"wumpgo.dev/wumpgo/objects"
@@ -19,3 +21,52 @@ func (c *Client) GetVoiceRegions(ctx context.Context) ([]*objects.VoiceRegion, e
 
 	return regions, err
 }
+
+type ModifyCurrentUserVoiceStateParams struct {
+	// ChannelID is the stage channel the user is currently in.
+	ChannelID objects.Snowflake `json:"channel_id,omitempty"`
+	// Suppress moves the user to the audience when true and to the
+	// speakers when false, the latter requires the MuteMembers permission.
+	Suppress *bool `json:"suppress,omitempty"`
+	// RequestToSpeakTimestamp raises the user's hand, it may be in the past
+	// or the future. Null lowers it again.
+	RequestToSpeakTimestamp *Nullable[objects.Time] `json:"request_to_speak_timestamp,omitempty"`
+}
+
+type ModifyUserVoiceStateParams struct {
+	// ChannelID is the stage channel the user is currently in.
+	ChannelID objects.Snowflake `json:"channel_id"`
+	// Suppress moves the user to the audience when true and to the
+	// speakers when false, the latter requires the MuteMembers permission.
+	Suppress *bool `json:"suppress,omitempty"`
+}
+
+func (c *Client) ModifyCurrentUserVoiceState(ctx context.Context, guild objects.Snowflake, params *ModifyCurrentUserVoiceStateParams) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	return NewRequest().
+		Method(http.MethodPatch).
+		WithContext(ctx).
+		Path(fmt.Sprintf(GuildVoiceStateMeFmt, guild)).
+		ContentType(JsonContentType).
+		Body(data).
+		Send(c)
+}
+
+func (c *Client) ModifyUserVoiceState(ctx context.Context, guild, user objects.Snowflake, params *ModifyUserVoiceStateParams) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	return NewRequest().
+		Method(http.MethodPatch).
+		WithContext(ctx).
+		Path(fmt.Sprintf(GuildVoiceStateFmt, guild, user)).
+		ContentType(JsonContentType).
+		Body(data).
+		Send(c)
+}